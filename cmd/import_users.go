@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/db"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/importer"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/services"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+)
+
+// importActor is the audit log actor for rows written by the import-users subcommand.
+var importActor = "cli:import-users"
+
+// runImportUsers implements the import-users subcommand:
+//
+//	go run ./cmd import-users -file users.csv [-format csv|jsonl] [-dry-run] [-batch-size 500] [-report report.json]
+func runImportUsers(args []string) {
+	flags := flag.NewFlagSet("import-users", flag.ExitOnError)
+	filePath := flags.String("file", "", "CSV or JSONL file to import (required)")
+	formatName := flags.String("format", "", "csv or jsonl (default: inferred from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate rows and check conflicts without writing")
+	batchSize := flags.Int("batch-size", 0, "rows per multi-row upsert (default: IMPORT_BATCH_SIZE)")
+	reportPath := flags.String("report", "", "write the JSON report to this file instead of stdout")
+	flags.Parse(args)
+
+	if *filePath == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var format importer.Format
+	var err error
+	if *formatName != "" {
+		format, err = importer.ParseFormat(*formatName)
+	} else {
+		format, err = importer.DetectFormat(*filePath)
+	}
+	if err != nil {
+		renderError(err.Error())
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+
+	if err := utils.InitLogger("log/user-service.log"); err != nil {
+		renderError(fmt.Sprintf("Failed to initialize logger: %v", err))
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+
+	database, err := db.ConnectDB(cfg)
+	if err != nil {
+		renderError(fmt.Sprintf("Database connection failed: %v", err))
+		log.Fatalf("Database connection failed: %v", err)
+	}
+	defer database.Close()
+
+	if err := db.Migrate(database); err != nil {
+		renderError(fmt.Sprintf("Database migration failed: %v", err))
+		log.Fatalf("Database migration failed: %v", err)
+	}
+
+	userService := newUserService(cfg, database)
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		renderError(fmt.Sprintf("Failed to open %s: %v", *filePath, err))
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	reader, err := importer.NewReader(file, format)
+	if err != nil {
+		renderError(err.Error())
+		os.Exit(1)
+	}
+
+	renderAction(fmt.Sprintf("Importing users from %s (format: %s, dry run: %t)", *filePath, format, *dryRun))
+	report, err := userService.RunImport(reader, services.ImportOptions{
+		DryRun:    *dryRun,
+		BatchSize: *batchSize,
+		Audit:     models.AuditEvent{ActorSubject: &importActor},
+	})
+	if err != nil {
+		renderError(fmt.Sprintf("Import failed: %v", err))
+		os.Exit(1)
+	}
+
+	output, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(report)
+	if err != nil {
+		renderError(fmt.Sprintf("Failed to encode report: %v", err))
+		os.Exit(1)
+	}
+	if *reportPath != "" {
+		if err := os.WriteFile(*reportPath, output, 0644); err != nil {
+			renderError(fmt.Sprintf("Failed to write report: %v", err))
+			os.Exit(1)
+		}
+	} else {
+		fmt.Println(string(output))
+	}
+
+	summary := fmt.Sprintf("Rows: %d | Created: %d | Updated: %d | Valid: %d | Failed: %d",
+		report.TotalRows, report.Created, report.Updated, report.Valid, report.Failed)
+	if report.Failed > 0 {
+		renderError(summary)
+		os.Exit(1)
+	}
+	renderSuccess(summary)
+}
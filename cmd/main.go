@@ -1,22 +1,37 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"os"
 	"time"
+	_ "time/tzdata" // Embedded IANA zones for timezone validation on minimal images
 
 	"github.com/fatih/color"
+	"google.golang.org/grpc"
 
 	"github.com/xIndustries/BandRoom/backend-auth/config"
 	"github.com/xIndustries/BandRoom/backend-auth/db"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/events"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/handlers"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/interceptors"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/ratelimit"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/server"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/services"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import-users" {
+		runImportUsers(os.Args[2:])
+		return
+	}
+
 	showStartupBanner()
 
 	// Load configuration
@@ -44,28 +59,195 @@ func main() {
 	defer database.Close()
 	renderSuccess("Connected to the database successfully")
 
-	// Initialize repositories
-	userRepo := repositories.NewUserRepository(database)
-	renderStep("User repository initialized")
+	// Apply pending schema migrations
+	if err := db.Migrate(database); err != nil {
+		renderError(fmt.Sprintf("Database migration failed: %v", err))
+		log.Fatalf("Database migration failed: %v", err)
+	}
+	renderSuccess("Database migrations applied successfully")
 
-	// Initialize services
-	userService := services.NewUserService(userRepo)
+	// Initialize repositories and services
+	userService := newUserService(cfg, database)
 	renderStep("User service initialized")
 
+	auditService := services.NewAuditService(repositories.NewAuditRepository(database))
+	renderStep("Audit service initialized")
+
+	sessionService := services.NewSessionService(repositories.NewSessionRepository(database), userService.Repo, auditService, cfg)
+	renderStep("Session service initialized")
+
+	denylistService := services.NewDenylistService(repositories.NewDenylistRepository(database), auditService, cfg)
+	go denylistService.RunGarbageCollector(context.Background(), cfg.TokenDenylistGCInterval)
+	renderStep("Token denylist initialized")
+
+	publisher, err := newEventPublisher(cfg)
+	if err != nil {
+		renderError(fmt.Sprintf("Failed to initialize event publisher: %v", err))
+		log.Fatalf("Failed to initialize event publisher: %v", err)
+	}
+	webhookService := services.NewWebhookService(repositories.NewWebhookRepository(database), auditService, cfg)
+	go webhookService.RunDispatcher(context.Background(), cfg.WebhookDispatchInterval)
+	renderStep("Webhook dispatcher initialized")
+
+	outboxRelay := services.NewOutboxRelay(repositories.NewOutboxRepository(database), events.MultiPublisher{publisher, webhookService}, cfg)
+	go outboxRelay.Run(context.Background(), cfg.OutboxRelayInterval)
+	go outboxRelay.RunGarbageCollector(context.Background(), cfg.OutboxGCInterval)
+	renderStep(fmt.Sprintf("Outbox relay initialized (%s publisher)", cfg.OutboxPublisher))
+
+	watchService := services.NewWatchService(repositories.NewOutboxRepository(database), cfg)
+	if listener, err := db.Listen(cfg, "user_events"); err != nil {
+		renderError(fmt.Sprintf("Change feed notifications unavailable, polling every %s: %v", cfg.WatchPollInterval, err))
+	} else {
+		defer listener.Close()
+		go watchService.RunNotifications(context.Background(), listener)
+	}
+	renderStep("Watch service initialized")
+
+	dataExportService := services.NewDataExportService(repositories.NewDataExportRepository(database), userService.Repo, auditService, cfg)
+	go dataExportService.RunWorker(context.Background(), cfg.DataExportWorkerInterval)
+	go dataExportService.RunGarbageCollector(context.Background(), cfg.DataExportGCInterval)
+	renderStep("Data export worker initialized")
+
+	consentService := services.NewConsentService(repositories.NewConsentRepository(database), userService.Repo, auditService)
+	renderStep("Consent service initialized")
+
+	var roleSyncer services.RoleSyncer
+	if cfg.Auth0RoleSync {
+		roleSyncer = auth.NewManagementClient(cfg.Auth0Domain, cfg.Auth0ClientID, cfg.Auth0ClientSecret)
+	}
+	roleService := services.NewRoleService(repositories.NewRoleRepository(database), userService.Repo, auditService, roleSyncer)
+	renderStep("Role service initialized")
+
+	moderationService := services.NewModerationService(userService.Repo, auditService, roleService)
+	go moderationService.RunSuspensionLifter(context.Background(), cfg.SuspensionLiftInterval)
+	renderStep("Moderation service initialized")
+
+	impersonationService := services.NewImpersonationService(repositories.NewImpersonationRepository(database), userService.Repo, auditService, cfg, impersonationBlockedMethods...)
+	renderStep("Impersonation service initialized")
+
 	// Initialize handlers
-	userHandler := handlers.NewUserHandler(userService)
+	userHandler := handlers.NewUserHandler(userService, sessionService, denylistService, watchService, webhookService, auditService, dataExportService, consentService, roleService, moderationService, impersonationService)
 	renderStep("User handler initialized")
 
+	// Initialize interceptors
+	var verifier *auth.Verifier
+	if cfg.Auth0Domain != "" {
+		verifier = auth.NewVerifier(cfg.Auth0Domain, cfg.Auth0Audience)
+	}
+	authInterceptor := interceptors.NewAuthInterceptor(verifier, cfg.AuthRequired, roleService, moderationService, impersonationService, sessionService, denylistService)
+	renderStep("Auth interceptor initialized")
+
+	if err := utils.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		renderError(fmt.Sprintf("Invalid trusted proxy configuration: %v", err))
+		log.Fatalf("Invalid trusted proxy configuration: %v", err)
+	}
+
+	rateLimitPolicy, err := ratelimit.ParsePolicy(cfg.RateLimitDefault, cfg.RateLimits)
+	if err != nil {
+		renderError(fmt.Sprintf("Invalid rate limit configuration: %v", err))
+		log.Fatalf("Invalid rate limit configuration: %v", err)
+	}
+	rateLimitInterceptor := interceptors.NewRateLimitInterceptor(ratelimit.NewMemoryLimiter(), rateLimitPolicy)
+	renderStep("Rate limit interceptor initialized")
+
+	idempotencyService := services.NewIdempotencyService(repositories.NewIdempotencyRepository(database), cfg)
+	go idempotencyService.RunGarbageCollector(context.Background(), cfg.IdempotencyGCInterval)
+	idempotencyInterceptor := interceptors.NewIdempotencyInterceptor(idempotencyService,
+		pb.UserService_CreateUser_FullMethodName,
+		pb.UserService_UpdateUser_FullMethodName,
+		pb.UserService_DeleteUser_FullMethodName,
+	)
+	renderStep("Idempotency interceptor initialized")
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{authInterceptor.Unary(), rateLimitInterceptor.Unary()}
+	streamInterceptors := []grpc.StreamServerInterceptor{authInterceptor.Stream(), rateLimitInterceptor.Stream()}
+	if cfg.RequireTerms {
+		consentInterceptor := interceptors.NewConsentInterceptor(consentService, termsExemptMethods...)
+		unaryInterceptors = append(unaryInterceptors, consentInterceptor.Unary())
+		streamInterceptors = append(streamInterceptors, consentInterceptor.Stream())
+		renderStep("Consent interceptor initialized")
+	}
+	unaryInterceptors = append(unaryInterceptors, idempotencyInterceptor.Unary())
+
 	// Start gRPC server
 	serverPort := cfg.GRPCPort
 	renderAction(fmt.Sprintf("Starting gRPC server on port %s", serverPort))
-	if err := server.RunGRPCServer(serverPort, userHandler); err != nil {
+	err = server.RunGRPCServer(serverPort, userHandler,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	if err != nil {
 		renderError(fmt.Sprintf("Failed to start gRPC server: %v", err))
 		log.Fatalf("Failed to start gRPC server: %v", err)
 	}
 	renderSuccess(fmt.Sprintf("gRPC server is listening on port %s", serverPort))
 }
 
+// termsExemptMethods stay available to users who have not accepted the current terms: signing up,
+// reviewing and accepting the documents, and exercising data rights.
+var termsExemptMethods = []string{
+	pb.UserService_CreateUser_FullMethodName,
+	pb.UserService_GetUser_FullMethodName,
+	pb.UserService_CheckUsernameAvailability_FullMethodName,
+	pb.UserService_DeleteUser_FullMethodName,
+	pb.UserService_RecordLogin_FullMethodName,
+	pb.UserService_RegisterSession_FullMethodName,
+	pb.UserService_RevokeSession_FullMethodName,
+	pb.UserService_RevokeAllSessions_FullMethodName,
+	pb.UserService_AcceptTerms_FullMethodName,
+	pb.UserService_GetConsents_FullMethodName,
+	pb.UserService_UpdateMarketingPreferences_FullMethodName,
+	pb.UserService_ExportMyData_FullMethodName,
+	pb.UserService_GetDataExport_FullMethodName,
+}
+
+// impersonationBlockedMethods cannot be called while impersonating: they are irreversible, change the
+// user's identity, act on their legal consent or hand out their data, would record sessions and
+// logins the user never made, or would chain impersonations.
+var impersonationBlockedMethods = []string{
+	pb.UserService_DeleteUser_FullMethodName,
+	pb.UserService_EraseUser_FullMethodName,
+	pb.UserService_UpdateUser_FullMethodName,
+	pb.UserService_UpdateUsername_FullMethodName,
+	pb.UserService_RecordLogin_FullMethodName,
+	pb.UserService_RegisterSession_FullMethodName,
+	pb.UserService_RevokeSession_FullMethodName,
+	pb.UserService_RevokeAllSessions_FullMethodName,
+	pb.UserService_AcceptTerms_FullMethodName,
+	pb.UserService_UpdateMarketingPreferences_FullMethodName,
+	pb.UserService_ExportMyData_FullMethodName,
+	pb.UserService_GetDataExport_FullMethodName,
+	pb.UserService_Impersonate_FullMethodName,
+}
+
+// newUserService wires the repositories into a UserService.
+func newUserService(cfg *config.Config, database *sql.DB) *services.UserService {
+	userRepo := repositories.NewUserRepository(database)
+	renderStep("User repository initialized")
+
+	usernameRepo := repositories.NewUsernameRepository(database)
+	renderStep("Username repository initialized")
+
+	loginRepo := repositories.NewLoginRepository(database)
+	renderStep("Login repository initialized")
+
+	return services.NewUserService(userRepo, usernameRepo, loginRepo, cfg)
+}
+
+// newEventPublisher returns the publisher selected by OUTBOX_PUBLISHER.
+func newEventPublisher(cfg *config.Config) (events.EventPublisher, error) {
+	switch cfg.OutboxPublisher {
+	case "log":
+		return events.LogPublisher{}, nil
+	case "file":
+		return events.NewFilePublisher(cfg.OutboxEventsFile)
+	case "none":
+		return events.DiscardPublisher{}, nil
+	default:
+		return nil, fmt.Errorf("unknown OUTBOX_PUBLISHER %q (want log, file or none)", cfg.OutboxPublisher)
+	}
+}
+
 func showStartupBanner() {
 	color.Cyan(`
 ==========================================================
@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +21,69 @@ type Config struct {
 	Auth0Domain       string
 	Auth0ClientID     string
 	Auth0ClientSecret string
+	Auth0Audience     string
+	AuthRequired      bool
+	RequireTerms      bool
+	Auth0RoleSync     bool
+
+	ReservedUsernames      []string
+	BlockedUsernameTerms   []string
+	UsernameReservationTTL time.Duration
+	UsernameChangeCooldown time.Duration
+	UsernameHoldPeriod     time.Duration
+
+	BatchGetUsersLimit int
+	ImportBatchSize    int
+
+	RefreshTokenMaxLifetime time.Duration
+	TokenMaxLifetime        time.Duration
+	TokenDenylistGCInterval time.Duration
+
+	RateLimitDefault string
+	RateLimits       []string
+	TrustedProxies   []string
+
+	IdempotencyKeyTTL     time.Duration
+	IdempotencyGCInterval time.Duration
+
+	OutboxPublisher     string
+	OutboxEventsFile    string
+	OutboxRelayInterval time.Duration
+	OutboxBatchSize     int
+	OutboxRetention     time.Duration
+	OutboxGCInterval    time.Duration
+	WatchPollInterval   time.Duration
+
+	WebhookAllowInsecureURLs bool
+	WebhookMaxAttempts       int
+	WebhookBackoffBase       time.Duration
+	WebhookBackoffMax        time.Duration
+	WebhookTimeout           time.Duration
+	WebhookBatchSize         int
+	WebhookDispatchInterval  time.Duration
+
+	DataExportRetention      time.Duration
+	DataExportWorkerInterval time.Duration
+	DataExportGCInterval     time.Duration
+
+	SuspensionLiftInterval time.Duration
+	ImpersonationTTL       time.Duration
+}
+
+// defaultReservedUsernames are names that can never be claimed by a regular account.
+var defaultReservedUsernames = []string{
+	"admin", "administrator", "root", "system", "support", "help", "staff",
+	"moderator", "mod", "official", "bandroom", "xindustries", "api", "www",
+	"settings", "account", "me", "null", "undefined",
+}
+
+// defaultRateLimits are per-RPC overrides ("<RPC>=<count>/<period>") for the calls most open to abuse.
+var defaultRateLimits = []string{
+	"CreateUser=5/m",
+	"UpdateUsername=5/h",
+	"CheckUsernameAvailability=60/m",
+	"ImportUsers=off",
+	"ExportUsers=off",
 }
 
 // LoadConfig loads the application configuration from the .env file.
@@ -39,6 +105,53 @@ func LoadConfig() *Config {
 		Auth0Domain:       getEnv("AUTH0_DOMAIN", ""),
 		Auth0ClientID:     getEnv("AUTH0_CLIENT_ID", ""),
 		Auth0ClientSecret: getEnv("AUTH0_CLIENT_SECRET", ""),
+		Auth0Audience:     getEnv("AUTH0_AUDIENCE", ""),
+		AuthRequired:      getEnvBool("AUTH_REQUIRED", false),
+		RequireTerms:      getEnvBool("REQUIRE_TERMS_ACCEPTANCE", false),
+		Auth0RoleSync:     getEnvBool("AUTH0_ROLE_SYNC", false),
+
+		ReservedUsernames:      getEnvList("RESERVED_USERNAMES", defaultReservedUsernames),
+		BlockedUsernameTerms:   getEnvList("BLOCKED_USERNAME_TERMS", nil),
+		UsernameReservationTTL: getEnvDuration("USERNAME_RESERVATION_TTL", 10*time.Minute),
+		UsernameChangeCooldown: getEnvDuration("USERNAME_CHANGE_COOLDOWN", 30*24*time.Hour),
+		UsernameHoldPeriod:     getEnvDuration("USERNAME_HOLD_PERIOD", 90*24*time.Hour),
+
+		BatchGetUsersLimit: getEnvInt("BATCH_GET_USERS_LIMIT", 100),
+		ImportBatchSize:    getEnvInt("IMPORT_BATCH_SIZE", 500),
+
+		RefreshTokenMaxLifetime: getEnvDuration("REFRESH_TOKEN_MAX_LIFETIME", 30*24*time.Hour),
+		TokenMaxLifetime:        getEnvDuration("TOKEN_MAX_LIFETIME", 24*time.Hour),
+		TokenDenylistGCInterval: getEnvDuration("TOKEN_DENYLIST_GC_INTERVAL", time.Hour),
+
+		RateLimitDefault: getEnv("RATE_LIMIT_DEFAULT", "20/s"),
+		RateLimits:       getEnvList("RATE_LIMITS", defaultRateLimits),
+		TrustedProxies:   getEnvList("TRUSTED_PROXIES", nil),
+
+		IdempotencyKeyTTL:     getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		IdempotencyGCInterval: getEnvDuration("IDEMPOTENCY_GC_INTERVAL", time.Hour),
+
+		OutboxPublisher:     getEnv("OUTBOX_PUBLISHER", "log"),
+		OutboxEventsFile:    getEnv("OUTBOX_EVENTS_FILE", "log/user-events.jsonl"),
+		OutboxRelayInterval: getEnvDuration("OUTBOX_RELAY_INTERVAL", time.Second),
+		OutboxBatchSize:     getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		OutboxRetention:     getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+		OutboxGCInterval:    getEnvDuration("OUTBOX_GC_INTERVAL", time.Hour),
+		WatchPollInterval:   getEnvDuration("WATCH_POLL_INTERVAL", 2*time.Second),
+
+		WebhookAllowInsecureURLs: getEnvBool("WEBHOOK_ALLOW_INSECURE_URLS", false),
+		WebhookMaxAttempts:       getEnvInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		WebhookBackoffBase:       getEnvDuration("WEBHOOK_BACKOFF_BASE", 30*time.Second),
+		WebhookBackoffMax:        getEnvDuration("WEBHOOK_BACKOFF_MAX", 6*time.Hour),
+		WebhookTimeout:           getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookBatchSize:         getEnvInt("WEBHOOK_BATCH_SIZE", 20),
+		WebhookDispatchInterval:  getEnvDuration("WEBHOOK_DISPATCH_INTERVAL", 5*time.Second),
+
+		DataExportRetention:      getEnvDuration("DATA_EXPORT_RETENTION", 7*24*time.Hour),
+		DataExportWorkerInterval: getEnvDuration("DATA_EXPORT_WORKER_INTERVAL", 5*time.Second),
+		DataExportGCInterval:     getEnvDuration("DATA_EXPORT_GC_INTERVAL", time.Hour),
+
+		SuspensionLiftInterval: getEnvDuration("SUSPENSION_LIFT_INTERVAL", time.Minute),
+		ImpersonationTTL:       getEnvDuration("IMPERSONATION_TTL", 15*time.Minute),
 	}
 }
 
@@ -49,3 +162,64 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvList retrieves a comma-separated environment variable as a list or provides a default value.
+func getEnvList(key string, fallback []string) []string {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvInt retrieves an integer environment variable or provides a default value.
+func getEnvInt(key string, fallback int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid integer for %s (%q), using %d", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvBool retrieves a boolean environment variable ("true", "1", ...) or provides a default value.
+func getEnvBool(key string, fallback bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Warning: invalid boolean for %s (%q), using %t", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvDuration retrieves a duration environment variable (e.g. "10m") or provides a default value.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: invalid duration for %s (%q), using %s", key, value, fallback)
+		return fallback
+	}
+	return duration
+}
@@ -4,21 +4,27 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/xIndustries/BandRoom/backend-auth/config"
 )
 
-// ConnectDB initializes a connection to the PostgreSQL database.
-func ConnectDB(cfg *config.Config) (*sql.DB, error) {
-	// Construct the connection string
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+// dataSourceName builds the PostgreSQL connection string from the configuration.
+// Timestamp columns are TIMESTAMP (without time zone) and hold UTC: sessions run with TimeZone=UTC
+// so NOW() defaults agree with bound values, and Go code binds times as .UTC() since PostgreSQL
+// drops the offset of a value cast to TIMESTAMP.
+func dataSourceName(cfg *config.Config) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s timezone=UTC",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBSSLMode,
 	)
+}
 
+// ConnectDB initializes a connection to the PostgreSQL database.
+func ConnectDB(cfg *config.Config) (*sql.DB, error) {
 	// Open a connection to the database
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("postgres", dataSourceName(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -31,3 +37,21 @@ func ConnectDB(cfg *config.Config) (*sql.DB, error) {
 	log.Println("Successfully connected to the database")
 	return db, nil
 }
+
+// Listen opens a dedicated connection subscribed to the given NOTIFY channels.
+// It reconnects on its own; a nil notification on Notify signals a reconnect, after which
+// listeners should assume they missed notifications.
+func Listen(cfg *config.Config, channels ...string) (*pq.Listener, error) {
+	listener := pq.NewListener(dataSourceName(cfg), 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("❌ Database listener: %v", err)
+		}
+	})
+	for _, channel := range channels {
+		if err := listener.Listen(channel); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("failed to listen on %s: %w", channel, err)
+		}
+	}
+	return listener, nil
+}
@@ -0,0 +1,8 @@
+-- Baseline users table (matches the schema documented in README.md).
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,                   -- Unique user ID (UUID)
+    auth0_id VARCHAR(255) UNIQUE NOT NULL, -- Auth0 unique identifier
+    email VARCHAR(255) UNIQUE NOT NULL,    -- User's email address
+    username VARCHAR(50),                  -- Optional username
+    created_at TIMESTAMP NOT NULL DEFAULT NOW() -- Timestamp of user creation
+);
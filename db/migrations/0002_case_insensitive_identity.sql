@@ -0,0 +1,7 @@
+-- Normalize stored emails and usernames, then enforce case-insensitive uniqueness.
+-- Fails if existing rows collide after normalization; resolve those duplicates first.
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+UPDATE users SET username = NULLIF(TRIM(username), '') WHERE username IS NOT NULL AND username <> TRIM(username);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_key ON users (LOWER(email));
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_lower_key ON users (LOWER(username));
@@ -0,0 +1,9 @@
+-- Short-lived username holds taken during signup so two people can't race for the same name.
+CREATE TABLE IF NOT EXISTS username_reservations (
+    username_key VARCHAR(50) PRIMARY KEY,  -- LOWER(username) being held
+    username VARCHAR(50) NOT NULL,         -- Username as requested
+    auth0_id VARCHAR(255) NOT NULL,        -- Auth0 ID of the account holding the reservation
+    expires_at TIMESTAMP NOT NULL          -- When the hold lapses
+);
+
+CREATE INDEX IF NOT EXISTS username_reservations_expires_at_idx ON username_reservations (expires_at);
@@ -0,0 +1,11 @@
+-- Every username change, used for the change cooldown and the hold on retired usernames.
+CREATE TABLE IF NOT EXISTS username_history (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    old_username VARCHAR(50),              -- Username retired by the change (NULL when first set)
+    new_username VARCHAR(50) NOT NULL,     -- Username taken by the change
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS username_history_user_id_idx ON username_history (user_id, changed_at DESC);
+CREATE INDEX IF NOT EXISTS username_history_old_username_idx ON username_history (LOWER(old_username), changed_at DESC);
@@ -0,0 +1,16 @@
+-- Soft deletes, plus indexes backing ListUsers (keyset on created_at, id) and SearchUsers (prefix/trigram).
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Deleted accounts release their email and username, so uniqueness only covers live rows.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS users_email_lower_key;
+DROP INDEX IF EXISTS users_username_lower_key;
+CREATE UNIQUE INDEX users_email_lower_key ON users (LOWER(email)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_username_lower_key ON users (LOWER(username)) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS users_created_at_id_idx ON users (created_at, id);
+CREATE INDEX IF NOT EXISTS users_email_domain_idx ON users (SPLIT_PART(LOWER(email), '@', 2));
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS users_username_trgm_idx ON users USING GIN (LOWER(username) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users_email_trgm_idx ON users USING GIN (LOWER(email) gin_trgm_ops);
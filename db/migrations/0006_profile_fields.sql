@@ -0,0 +1,8 @@
+-- Optional public profile fields shared with the rest of BandRoom.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(2048),
+    ADD COLUMN IF NOT EXISTS bio VARCHAR(500),
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35),       -- BCP 47 language tag
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64),     -- IANA time zone name
+    ADD COLUMN IF NOT EXISTS date_of_birth DATE;
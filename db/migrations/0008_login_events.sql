@@ -0,0 +1,12 @@
+-- Append-only login history; users.last_login_at mirrors the latest event.
+CREATE TABLE IF NOT EXISTS login_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ip_address VARCHAR(45),                -- IPv4 or IPv6 client address
+    user_agent VARCHAR(512),
+    app_version VARCHAR(50),               -- Client app version, e.g. "2.3.1 (145)"
+    auth_method VARCHAR(50)                -- Auth0 connection or strategy, e.g. "apple"
+);
+
+CREATE INDEX IF NOT EXISTS login_events_user_id_idx ON login_events (user_id, occurred_at DESC, id DESC);
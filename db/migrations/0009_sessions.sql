@@ -0,0 +1,19 @@
+-- Devices a user is signed in on. Revoking a session rejects every token of its token family.
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    device_id VARCHAR(255) NOT NULL,       -- Stable per-install identifier sent by the app
+    token_family VARCHAR(255),             -- Auth0 session ID ("sid") shared by the refresh token family
+    device_name VARCHAR(255),              -- e.g. "Gal's iPad"
+    platform VARCHAR(50),                  -- ios, ipados, android, web
+    app_version VARCHAR(50),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(512),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sessions_active_device_key ON sessions (user_id, device_id) WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id, last_seen_at DESC);
+CREATE INDEX IF NOT EXISTS sessions_revoked_family_idx ON sessions (revoked_at, token_family) WHERE revoked_at IS NOT NULL;
@@ -0,0 +1,23 @@
+-- Access tokens revoked before their natural expiry, individually (jti) or per user (issued before a cutoff).
+-- Rows are garbage-collected once every token they could match has expired.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(255) PRIMARY KEY,          -- Token ID claim
+    subject VARCHAR(255),                  -- Token subject (Auth0 ID), informational
+    expires_at TIMESTAMP NOT NULL,         -- Token expiry; the row can be dropped afterwards
+    reason VARCHAR(255),
+    revoked_by VARCHAR(255),               -- Auth0 ID of the admin who revoked it
+    revoked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS revoked_tokens_expires_at_idx ON revoked_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS user_token_revocations (
+    subject VARCHAR(255) PRIMARY KEY,      -- Auth0 ID whose tokens are revoked
+    revoked_before TIMESTAMP NOT NULL,     -- Tokens issued at or before this time are rejected
+    expires_at TIMESTAMP NOT NULL,         -- revoked_before plus the maximum token lifetime
+    reason VARCHAR(255),
+    revoked_by VARCHAR(255),
+    revoked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS user_token_revocations_expires_at_idx ON user_token_revocations (expires_at);
@@ -0,0 +1,13 @@
+-- Responses of mutating RPCs keyed by the client's "idempotency-key" header, so retries replay the original result.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    scope VARCHAR(255) NOT NULL,           -- Caller's Auth0 ID ('' for unauthenticated calls)
+    key VARCHAR(255) NOT NULL,             -- Client-chosen idempotency key
+    method VARCHAR(255) NOT NULL,          -- Full gRPC method name
+    request_hash BYTEA NOT NULL,           -- SHA-256 of the method and serialized request
+    response BYTEA,                        -- Serialized response (google.protobuf.Any); NULL while in progress
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
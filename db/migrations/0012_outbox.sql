@@ -0,0 +1,13 @@
+-- Transactional outbox: user lifecycle events written in the same transaction as the change,
+-- published by the relay worker and kept for a retention period so consumers can replay them.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,              -- Monotonic position of the event
+    event_type VARCHAR(50) NOT NULL,       -- UserCreated, UserUpdated, UserDeleted
+    user_id UUID NOT NULL,                 -- Aggregate the event belongs to
+    payload JSONB NOT NULL,                -- models.UserEventPayload
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMP                 -- Set once the relay has published the event
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_idx ON outbox_events (id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_events_created_at_idx ON outbox_events (created_at);
@@ -0,0 +1,22 @@
+-- WatchUsers reads the outbox in commit-safe order: events are ordered by the transaction that wrote
+-- them, and only transactions older than every in-flight one are read, so a late commit is never skipped.
+-- Requires PostgreSQL 13+ (xid8).
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS xact_id xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS outbox_events_position_idx ON outbox_events (xact_id, id);
+
+-- The outbox relay publishes unpublished events in the same order.
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_position_idx ON outbox_events (xact_id, id) WHERE published_at IS NULL;
+DROP INDEX IF EXISTS outbox_events_unpublished_idx;
+
+-- Wake up watchers as soon as new events commit (delivered on commit, coalesced per transaction).
+CREATE OR REPLACE FUNCTION notify_user_events() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('user_events', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS outbox_events_notify ON outbox_events;
+CREATE TRIGGER outbox_events_notify AFTER INSERT ON outbox_events
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_user_events();
@@ -0,0 +1,46 @@
+-- Outbound webhooks: partner subscriptions, one delivery per event and subscription, and the
+-- deliveries that exhausted their retries.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,          -- HMAC-SHA256 signing key shared with the partner
+    event_types TEXT[] NOT NULL,           -- Subscribed event types; empty means all
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255),               -- Auth0 ID of the admin who created it
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+DROP TRIGGER IF EXISTS webhook_subscriptions_set_updated_at ON webhook_subscriptions;
+CREATE TRIGGER webhook_subscriptions_set_updated_at BEFORE UPDATE ON webhook_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL,              -- Outbox event ID
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,                -- Request body
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, delivered, dead
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMP,
+    last_status_code INT,                  -- HTTP status of the last attempt, NULL if no response
+    last_error TEXT,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, id DESC);
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    delivery_id BIGINT PRIMARY KEY REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,43 @@
+-- Append-only audit trail of user mutations and admin actions. Each row's hash covers the previous
+-- row's hash, so editing, removing or reordering rows breaks the chain (see VerifyAuditLog).
+-- The field diff is covered through its digest, not its text, and the target Auth0 ID and client IP
+-- through salted digests, which cannot be matched against guessed values without the salt.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP NOT NULL,        -- UTC, microsecond precision (part of the hash)
+    actor_subject VARCHAR(255),            -- Auth0 ID of the caller, NULL for unauthenticated or system actions
+    action VARCHAR(100) NOT NULL,          -- e.g. user.created, user.username_changed, token.revoked
+    rpc VARCHAR(255),                      -- Full gRPC method name
+    target_user_id UUID,                   -- Database ID of the affected user, if any
+    target_auth0_id VARCHAR(255),
+    target_auth0_id_salt BYTEA,
+    target_auth0_id_digest BYTEA,          -- SHA-256 of salt and target_auth0_id
+    request_id VARCHAR(255),               -- Caller's x-request-id header
+    client_ip VARCHAR(45),
+    client_ip_salt BYTEA,
+    client_ip_digest BYTEA,                -- SHA-256 of salt and client_ip
+    changes JSON,                          -- {"field": {"old": ..., "new": ...}}
+    changes_digest BYTEA NOT NULL,         -- SHA-256 of changes as written
+    prev_hash BYTEA NOT NULL,
+    hash BYTEA NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log (actor_subject, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_target_user_idx ON audit_log (target_user_id, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_target_auth0_idx ON audit_log (target_auth0_id, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_action_idx ON audit_log (action, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_occurred_at_idx ON audit_log (occurred_at);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_no_update ON audit_log;
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
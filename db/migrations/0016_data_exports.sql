@@ -0,0 +1,18 @@
+-- Personal data export jobs (ExportMyData / ExportUserData). The worker builds the JSON archive
+-- asynchronously; it is kept until expires_at and then dropped with the job.
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    requested_by VARCHAR(255),             -- Auth0 ID of the caller (the user or an admin)
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, running, succeeded, failed
+    archive BYTEA,                         -- JSON archive, set once succeeded
+    error TEXT,                            -- Set once failed
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,                  -- When a worker last claimed the job
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL          -- When the job and its archive are deleted
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx ON data_exports (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS data_exports_pending_idx ON data_exports (created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS data_exports_expires_at_idx ON data_exports (expires_at);
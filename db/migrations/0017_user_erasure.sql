@@ -0,0 +1,41 @@
+-- Right-to-erasure support. EraseUser keeps the users row (and its UUID, referenced elsewhere)
+-- but replaces its personal data with tombstones and scrubs the related tables.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS erasure_certificates (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users (id),
+    requested_by VARCHAR(255),             -- Auth0 ID of the admin who erased the user
+    reason VARCHAR(255),                   -- e.g. a support ticket reference
+    scrubbed JSONB NOT NULL,               -- Rows scrubbed or deleted, keyed by table
+    remaining JSONB NOT NULL DEFAULT '[]', -- Known copies of the user's data the erasure could not reach
+    completed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Audit entries may have their field diff redacted once, and a digested value (target_auth0_id or
+-- client_ip) nulled together with its salt. Every hashed column stays as written, so the chain still
+-- verifies. Everything else stays append-only.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS redacted_at TIMESTAMP;
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND (NEW.id, NEW.occurred_at, NEW.actor_subject, NEW.action, NEW.rpc, NEW.target_user_id,
+             NEW.request_id, NEW.changes_digest, NEW.target_auth0_id_digest, NEW.client_ip_digest,
+             NEW.prev_hash, NEW.hash)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.occurred_at, OLD.actor_subject, OLD.action, OLD.rpc, OLD.target_user_id,
+             OLD.request_id, OLD.changes_digest, OLD.target_auth0_id_digest, OLD.client_ip_digest,
+             OLD.prev_hash, OLD.hash)
+        AND ((NEW.changes::text IS NOT DISTINCT FROM OLD.changes::text AND NEW.redacted_at IS NOT DISTINCT FROM OLD.redacted_at)
+             OR (OLD.redacted_at IS NULL AND NEW.redacted_at IS NOT NULL AND NEW.changes IS NULL))
+        AND ((NEW.target_auth0_id, NEW.target_auth0_id_salt) IS NOT DISTINCT FROM (OLD.target_auth0_id, OLD.target_auth0_id_salt)
+             OR (OLD.target_auth0_id_digest IS NOT NULL AND NEW.target_auth0_id IS NULL AND NEW.target_auth0_id_salt IS NULL))
+        AND ((NEW.client_ip, NEW.client_ip_salt) IS NOT DISTINCT FROM (OLD.client_ip, OLD.client_ip_salt)
+             OR (OLD.client_ip_digest IS NOT NULL AND NEW.client_ip IS NULL AND NEW.client_ip_salt IS NULL))
+    THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
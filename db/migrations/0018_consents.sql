@@ -0,0 +1,28 @@
+-- Versioned legal documents and each user's consent decisions. A document kind's current version
+-- is the one with the latest effective_at that has passed.
+CREATE TABLE IF NOT EXISTS legal_documents (
+    kind VARCHAR(20) NOT NULL,             -- terms, privacy
+    version VARCHAR(50) NOT NULL,          -- e.g. "2025-01-15"
+    url VARCHAR(2048) NOT NULL,            -- Where the text is published
+    effective_at TIMESTAMP NOT NULL,       -- When the version becomes the one users must accept
+    created_by VARCHAR(255),               -- Auth0 ID of the admin who published it
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kind, version)
+);
+
+CREATE INDEX IF NOT EXISTS legal_documents_current_idx ON legal_documents (kind, effective_at DESC);
+
+-- Append-only history of decisions; a user's current state per kind is the latest row.
+CREATE TABLE IF NOT EXISTS user_consents (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,             -- terms, privacy, marketing_email, marketing_push
+    version VARCHAR(50),                   -- Document version accepted (terms and privacy only)
+    granted BOOLEAN NOT NULL,              -- Marketing opt-in or opt-out; always TRUE for documents
+    source VARCHAR(50) NOT NULL,           -- Where the decision was made, e.g. ios_signup, web_settings
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(512),
+    decided_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS user_consents_user_kind_idx ON user_consents (user_id, kind, decided_at DESC, id DESC);
@@ -0,0 +1,24 @@
+-- Local roles. A user's effective permissions are their token's Auth0 permissions plus the
+-- permissions of every role assigned here.
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,          -- e.g. admin, support
+    description VARCHAR(255),
+    permissions TEXT[] NOT NULL,           -- Permissions granted, e.g. {read:user_emails}
+    auth0_role_id VARCHAR(255),            -- Auth0 role kept in sync with assignments (optional)
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    granted_by VARCHAR(255),               -- Auth0 ID of the admin who assigned it
+    granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS user_roles_role_idx ON user_roles (role);
+
+INSERT INTO roles (name, description, permissions) VALUES
+    ('admin', 'Full administrative access to users', '{admin:users}'),
+    ('support', 'Look users up by email address', '{read:user_emails}')
+ON CONFLICT (name) DO NOTHING;
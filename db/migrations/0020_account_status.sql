@@ -0,0 +1,16 @@
+-- Moderation state of an account. Suspensions with an expiry lift automatically; bans do not expire.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS status VARCHAR(30) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'suspended', 'banned', 'pending_verification')),
+    ADD COLUMN IF NOT EXISTS status_reason VARCHAR(500),      -- Shown to the user when their calls are rejected
+    ADD COLUMN IF NOT EXISTS status_expires_at TIMESTAMP,     -- When a suspension lifts (suspended only)
+    ADD COLUMN IF NOT EXISTS status_changed_by VARCHAR(255),  -- Auth0 ID of the moderator who set the status
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP;
+
+-- Restricted accounts are loaded by every instance, and expired suspensions are lifted in the background.
+CREATE INDEX IF NOT EXISTS users_restricted_idx ON users (status_expires_at)
+    WHERE status IN ('suspended', 'banned');
+
+INSERT INTO roles (name, description, permissions) VALUES
+    ('moderator', 'Suspend and ban users', '{moderate:users}')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,44 @@
+-- Short-lived grants letting support staff act as a user. Only a hash of the token is stored;
+-- the token itself is returned once by Impersonate.
+CREATE TABLE IF NOT EXISTS impersonations (
+    id UUID PRIMARY KEY,
+    token_hash BYTEA NOT NULL UNIQUE,      -- SHA-256 of the token
+    actor_subject VARCHAR(255) NOT NULL,   -- Auth0 ID of the staff member; only they can use the token
+    user_id UUID NOT NULL REFERENCES users (id),
+    reason VARCHAR(500) NOT NULL,          -- e.g. a support ticket reference
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS impersonations_user_id_idx ON impersonations (user_id);
+
+-- Actions taken while impersonating record the staff member next to the impersonated actor.
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS impersonator_subject VARCHAR(255);
+
+-- impersonator_subject is hashed like the other columns, so the trigger keeps it as written too.
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND (NEW.id, NEW.occurred_at, NEW.actor_subject, NEW.impersonator_subject, NEW.action, NEW.rpc,
+             NEW.target_user_id, NEW.request_id, NEW.changes_digest, NEW.target_auth0_id_digest,
+             NEW.client_ip_digest, NEW.prev_hash, NEW.hash)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.occurred_at, OLD.actor_subject, OLD.impersonator_subject, OLD.action, OLD.rpc,
+             OLD.target_user_id, OLD.request_id, OLD.changes_digest, OLD.target_auth0_id_digest,
+             OLD.client_ip_digest, OLD.prev_hash, OLD.hash)
+        AND ((NEW.changes::text IS NOT DISTINCT FROM OLD.changes::text AND NEW.redacted_at IS NOT DISTINCT FROM OLD.redacted_at)
+             OR (OLD.redacted_at IS NULL AND NEW.redacted_at IS NOT NULL AND NEW.changes IS NULL))
+        AND ((NEW.target_auth0_id, NEW.target_auth0_id_salt) IS NOT DISTINCT FROM (OLD.target_auth0_id, OLD.target_auth0_id_salt)
+             OR (OLD.target_auth0_id_digest IS NOT NULL AND NEW.target_auth0_id IS NULL AND NEW.target_auth0_id_salt IS NULL))
+        AND ((NEW.client_ip, NEW.client_ip_salt) IS NOT DISTINCT FROM (OLD.client_ip, OLD.client_ip_salt)
+             OR (OLD.client_ip_digest IS NOT NULL AND NEW.client_ip IS NULL AND NEW.client_ip_salt IS NULL))
+    THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Support staff reproduce user issues.
+UPDATE roles SET permissions = array_append(permissions, 'impersonate:users')
+WHERE name = 'support' AND NOT 'impersonate:users' = ANY (permissions);
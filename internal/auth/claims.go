@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Permissions granted through Auth0 RBAC or local roles that this service checks.
+const (
+	// PermissionAdmin grants every administrative operation on users.
+	PermissionAdmin = "admin:users"
+	// PermissionReadUserEmails allows looking up users by email address.
+	PermissionReadUserEmails = "read:user_emails"
+	// PermissionRecordLogins allows recording logins on behalf of any user (Auth0 login hook).
+	PermissionRecordLogins = "record:logins"
+	// PermissionWatchUsers allows subscribing to the user change feed (WatchUsers).
+	PermissionWatchUsers = "watch:users"
+	// PermissionModerateUsers allows suspending, banning and reinstating users.
+	PermissionModerateUsers = "moderate:users"
+	// PermissionImpersonateUsers allows acting as another user with a short-lived grant (Impersonate).
+	PermissionImpersonateUsers = "impersonate:users"
+)
+
+// ImpersonationHeader is the metadata header carrying an impersonation token, sent together with
+// the staff member's own bearer token.
+const ImpersonationHeader = "x-impersonation-token"
+
+// Claims holds the verified claims of an Auth0 access token.
+type Claims struct {
+	Subject     string    // Auth0 user ID ("sub")
+	ID          string    // Token ID ("jti")
+	SessionID   string    // Auth0 session / refresh token family ("sid")
+	Scope       string    // Space-separated OAuth scopes
+	Permissions []string  // Auth0 RBAC permissions
+	IssuedAt    time.Time // "iat"
+	ExpiresAt   time.Time // "exp"
+
+	Impersonator    string // Auth0 ID of the staff member acting as Subject, when impersonating
+	ImpersonationID string // Impersonation grant the call is made under
+}
+
+// HasPermission reports whether the token carries the permission, either as an
+// RBAC permission or as an OAuth scope. Admins implicitly hold every permission.
+func (c *Claims) HasPermission(permission string) bool {
+	if c == nil {
+		return false
+	}
+	scopes := strings.Fields(c.Scope)
+	for _, granted := range []string{permission, PermissionAdmin} {
+		if slices.Contains(c.Permissions, granted) || slices.Contains(scopes, granted) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsImpersonated reports whether a staff member is acting as the subject.
+func (c *Claims) IsImpersonated() bool {
+	return c != nil && c.Impersonator != ""
+}
+
+// IsAdmin reports whether the token grants administrative access.
+func (c *Claims) IsAdmin() bool {
+	return c.HasPermission(PermissionAdmin)
+}
+
+type claimsKey struct{}
+
+// NewContext returns a copy of ctx carrying the caller's claims.
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the caller's claims, or nil for unauthenticated calls.
+func FromContext(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(claimsKey{}).(*Claims)
+	return claims
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     *Claims
+		permission string
+		want       bool
+	}{
+		{"unauthenticated", nil, PermissionReadUserEmails, false},
+		{"no permissions", &Claims{Subject: "auth0|jane"}, PermissionReadUserEmails, false},
+		{"rbac permission", &Claims{Permissions: []string{PermissionReadUserEmails}}, PermissionReadUserEmails, true},
+		{"oauth scope", &Claims{Scope: "openid " + PermissionReadUserEmails}, PermissionReadUserEmails, true},
+		{"scope prefix only", &Claims{Scope: "read:user"}, PermissionReadUserEmails, false},
+		{"admin holds every permission", &Claims{Permissions: []string{PermissionAdmin}}, PermissionReadUserEmails, true},
+		{"admin scope", &Claims{Scope: PermissionAdmin}, PermissionReadUserEmails, true},
+		{"other permission", &Claims{Permissions: []string{PermissionReadUserEmails}}, PermissionAdmin, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.claims.HasPermission(tt.permission); got != tt.want {
+				t.Errorf("HasPermission(%q) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimsContext(t *testing.T) {
+	if claims := FromContext(context.Background()); claims != nil {
+		t.Errorf("FromContext() = %+v, want nil", claims)
+	}
+
+	claims := &Claims{Subject: "auth0|jane"}
+	if got := FromContext(NewContext(context.Background(), claims)); got != claims {
+		t.Errorf("FromContext() = %+v, want %+v", got, claims)
+	}
+}
+
+func TestIsImpersonated(t *testing.T) {
+	var unauthenticated *Claims
+	if unauthenticated.IsImpersonated() || (&Claims{Subject: "auth0|jane"}).IsImpersonated() {
+		t.Error("IsImpersonated() = true without an impersonator")
+	}
+	if !(&Claims{Subject: "auth0|jane", Impersonator: "auth0|staff"}).IsImpersonated() {
+		t.Error("IsImpersonated() = false with an impersonator")
+	}
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ManagementClient calls the Auth0 Management API with a machine-to-machine application's
+// client credentials. The application needs the read:roles and update:users scopes.
+type ManagementClient struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewManagementClient creates a ManagementClient for the Auth0 domain.
+func NewManagementClient(domain, clientID, clientSecret string) *ManagementClient {
+	return &ManagementClient{
+		baseURL:      fmt.Sprintf("https://%s", domain),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AssignRoles adds Auth0 roles to a user.
+func (c *ManagementClient) AssignRoles(ctx context.Context, userID string, roleIDs []string) error {
+	return c.updateRoles(ctx, http.MethodPost, userID, roleIDs)
+}
+
+// RemoveRoles removes Auth0 roles from a user.
+func (c *ManagementClient) RemoveRoles(ctx context.Context, userID string, roleIDs []string) error {
+	return c.updateRoles(ctx, http.MethodDelete, userID, roleIDs)
+}
+
+func (c *ManagementClient) updateRoles(ctx context.Context, method, userID string, roleIDs []string) error {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string][]string{"roles": roleIDs})
+	if err != nil {
+		return err
+	}
+	endpoint := c.baseURL + "/api/v2/users/" + url.PathEscape(userID) + "/roles"
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update Auth0 roles: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to update Auth0 roles: %s", resp.Status)
+	}
+	return nil
+}
+
+// accessToken returns a cached Management API token, requesting a new one shortly before it expires.
+func (c *ManagementClient) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Until(c.expiresAt) > time.Minute {
+		return c.token, nil
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     c.clientID,
+		"client_secret": c.clientSecret,
+		"audience":      c.baseURL + "/api/v2/",
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/oauth/token", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request Auth0 management token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to request Auth0 management token: %s", resp.Status)
+	}
+
+	var grant struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&grant); err != nil {
+		return "", fmt.Errorf("failed to decode Auth0 management token: %w", err)
+	}
+
+	c.token = grant.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(grant.ExpiresIn) * time.Second)
+	return c.token, nil
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+)
+
+// jwksRefreshInterval bounds how often an unknown key ID can trigger a JWKS refetch.
+const jwksRefreshInterval = 5 * time.Minute
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Verifier validates RS256 access tokens issued by an Auth0 tenant.
+type Verifier struct {
+	issuer   string
+	audience string
+	jwksURL  string
+	client   *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	lastFetched time.Time
+}
+
+// NewVerifier creates a Verifier for the Auth0 domain. When audience is empty the
+// "aud" claim is not checked.
+func NewVerifier(domain, audience string) *Verifier {
+	return &Verifier{
+		issuer:   fmt.Sprintf("https://%s/", domain),
+		audience: audience,
+		jwksURL:  fmt.Sprintf("https://%s/.well-known/jwks.json", domain),
+		client:   &http.Client{Timeout: 10 * time.Second},
+		keys:     make(map[string]*rsa.PublicKey),
+	}
+}
+
+type tokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type tokenPayload struct {
+	Issuer      string          `json:"iss"`
+	Subject     string          `json:"sub"`
+	Audience    json.RawMessage `json:"aud"`
+	ID          string          `json:"jti"`
+	SessionID   string          `json:"sid"`
+	Scope       string          `json:"scope"`
+	Permissions []string        `json:"permissions"`
+	IssuedAt    int64           `json:"iat"`
+	NotBefore   int64           `json:"nbf"`
+	ExpiresAt   int64           `json:"exp"`
+}
+
+// Verify checks the token signature, issuer, audience and lifetime and returns its claims.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	if err := utils.ValidateAuth0Token(token); err != nil {
+		return nil, ErrInvalidToken
+	}
+	parts := strings.Split(token, ".")
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "RS256" {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var payload tokenPayload
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if payload.Issuer != v.issuer || payload.Subject == "" || !v.audienceMatches(payload.Audience) {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if payload.ExpiresAt == 0 || now.After(time.Unix(payload.ExpiresAt, 0)) {
+		return nil, ErrExpiredToken
+	}
+	if payload.NotBefore != 0 && now.Before(time.Unix(payload.NotBefore, 0)) {
+		return nil, ErrInvalidToken
+	}
+
+	return &Claims{
+		Subject:     payload.Subject,
+		ID:          payload.ID,
+		SessionID:   payload.SessionID,
+		Scope:       payload.Scope,
+		Permissions: payload.Permissions,
+		IssuedAt:    time.Unix(payload.IssuedAt, 0),
+		ExpiresAt:   time.Unix(payload.ExpiresAt, 0),
+	}, nil
+}
+
+// audienceMatches accepts "aud" as either a single string or a list of strings.
+func (v *Verifier) audienceMatches(raw json.RawMessage) bool {
+	if v.audience == "" {
+		return true
+	}
+
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single == v.audience
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return slices.Contains(list, v.audience)
+	}
+	return false
+}
+
+// key returns the signing key for kid, refreshing the JWKS when the key is unknown.
+func (v *Verifier) key(kid string) (*rsa.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.lastFetched) > jwksRefreshInterval
+	v.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, ErrInvalidToken
+	}
+
+	if err := v.refreshKeys(); err != nil {
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrInvalidToken
+}
+
+type jwks struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// refreshKeys downloads the tenant's JWKS and replaces the cached keys.
+func (v *Verifier) refreshKeys() error {
+	resp, err := v.client.Get(v.jwksURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+
+	var set jwks
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.lastFetched = time.Now()
+	v.mu.Unlock()
+	return nil
+}
+
+// decodeSegment decodes a base64url JWT segment into v.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// signToken builds an RS256 token with the header and payload fields given.
+func signToken(t *testing.T, key *rsa.PrivateKey, header, payload map[string]interface{}) string {
+	t.Helper()
+	segment := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := segment(header) + "." + segment(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := NewVerifier("tenant.example.com", "https://api.example.com")
+	verifier.keys["k1"] = &key.PublicKey
+	verifier.lastFetched = time.Now() // keeps unknown key IDs from triggering a JWKS fetch
+
+	now := time.Now()
+	header := map[string]interface{}{"alg": "RS256", "kid": "k1"}
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		payload := map[string]interface{}{
+			"iss":         "https://tenant.example.com/",
+			"sub":         "auth0|jane",
+			"aud":         "https://api.example.com",
+			"jti":         "token-1",
+			"scope":       "openid read:user_emails",
+			"permissions": []string{"admin:users"},
+			"iat":         now.Add(-time.Minute).Unix(),
+			"exp":         now.Add(time.Hour).Unix(),
+		}
+		for field, value := range changes {
+			if value == nil {
+				delete(payload, field)
+			} else {
+				payload[field] = value
+			}
+		}
+		return payload
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: signToken(t, key, header, claims(nil))},
+		{name: "audience list", token: signToken(t, key, header, claims(map[string]interface{}{"aud": []string{"other", "https://api.example.com"}}))},
+		{name: "not a jwt", token: "not-a-token", wantErr: ErrInvalidToken},
+		{name: "unsupported algorithm", token: signToken(t, key, map[string]interface{}{"alg": "HS256", "kid": "k1"}, claims(nil)), wantErr: ErrInvalidToken},
+		{name: "unknown key", token: signToken(t, key, map[string]interface{}{"alg": "RS256", "kid": "k2"}, claims(nil)), wantErr: ErrInvalidToken},
+		{name: "signed with another key", token: signToken(t, otherKey, header, claims(nil)), wantErr: ErrInvalidToken},
+		{name: "other issuer", token: signToken(t, key, header, claims(map[string]interface{}{"iss": "https://evil.example.com/"})), wantErr: ErrInvalidToken},
+		{name: "other audience", token: signToken(t, key, header, claims(map[string]interface{}{"aud": "https://other.example.com"})), wantErr: ErrInvalidToken},
+		{name: "no subject", token: signToken(t, key, header, claims(map[string]interface{}{"sub": nil})), wantErr: ErrInvalidToken},
+		{name: "expired", token: signToken(t, key, header, claims(map[string]interface{}{"exp": now.Add(-time.Minute).Unix()})), wantErr: ErrExpiredToken},
+		{name: "no expiry", token: signToken(t, key, header, claims(map[string]interface{}{"exp": nil})), wantErr: ErrExpiredToken},
+		{name: "not yet valid", token: signToken(t, key, header, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})), wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Subject != "auth0|jane" || got.ID != "token-1" || !got.HasPermission(PermissionReadUserEmails) || !got.IsAdmin() {
+				t.Errorf("Verify() = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// InProcessPublisher fans events out to subscribers in the same process, e.g. tests or local consumers.
+type InProcessPublisher struct {
+	mu          sync.RWMutex
+	subscribers map[int]chan *models.OutboxEvent
+	nextID      int
+	buffer      int
+}
+
+// NewInProcessPublisher creates a publisher whose subscriber channels hold up to buffer events.
+func NewInProcessPublisher(buffer int) *InProcessPublisher {
+	return &InProcessPublisher{subscribers: make(map[int]chan *models.OutboxEvent), buffer: buffer}
+}
+
+// Subscribe returns a channel receiving every event published from now on, and a function that
+// unsubscribes and closes it.
+func (p *InProcessPublisher) Subscribe() (<-chan *models.OutboxEvent, func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := p.nextID
+	p.nextID++
+	ch := make(chan *models.OutboxEvent, p.buffer)
+	p.subscribers[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			delete(p.subscribers, id)
+			close(ch)
+		})
+	}
+}
+
+// Publish implements EventPublisher. It waits for every subscriber to accept the event, so a slow
+// subscriber holds back the relay rather than losing events.
+func (p *InProcessPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, ch := range p.subscribers {
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+func TestInProcessPublisherDeliversToEverySubscriber(t *testing.T) {
+	publisher := NewInProcessPublisher(2)
+	first, unsubscribeFirst := publisher.Subscribe()
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := publisher.Subscribe()
+	defer unsubscribeSecond()
+
+	events := []*models.OutboxEvent{
+		{ID: 1, EventType: models.EventUserCreated, UserID: "u1"},
+		{ID: 2, EventType: models.EventUserUpdated, UserID: "u1"},
+	}
+	for _, event := range events {
+		if err := publisher.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Publish(%d) = %v", event.ID, err)
+		}
+	}
+
+	for name, ch := range map[string]<-chan *models.OutboxEvent{"first": first, "second": second} {
+		for _, want := range events {
+			if got := <-ch; got != want {
+				t.Errorf("%s subscriber got event %d, want %d", name, got.ID, want.ID)
+			}
+		}
+	}
+}
+
+func TestInProcessPublisherUnsubscribe(t *testing.T) {
+	publisher := NewInProcessPublisher(1)
+	ch, unsubscribe := publisher.Subscribe()
+	unsubscribe()
+	unsubscribe() // must be safe to call twice
+
+	if _, open := <-ch; open {
+		t.Fatal("channel still open after unsubscribe")
+	}
+	if err := publisher.Publish(context.Background(), &models.OutboxEvent{ID: 1}); err != nil {
+		t.Fatalf("Publish without subscribers = %v", err)
+	}
+}
+
+func TestInProcessPublisherWaitsForSlowSubscriber(t *testing.T) {
+	publisher := NewInProcessPublisher(0)
+	_, unsubscribe := publisher.Subscribe()
+	defer unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := publisher.Publish(ctx, &models.OutboxEvent{ID: 1}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Publish to a full subscriber = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
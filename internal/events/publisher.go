@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// EventPublisher delivers outbox events to other services. The relay publishes events in outbox
+// order and at least once, so consumers must tolerate duplicates (deduplicate on event ID).
+type EventPublisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
+
+// DiscardPublisher drops every event; outbox rows are still written and marked published.
+type DiscardPublisher struct{}
+
+// Publish implements EventPublisher.
+func (DiscardPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	return nil
+}
+
+// MultiPublisher publishes every event to each of its publishers in turn, stopping at the first error.
+// Since the relay retries failed events, publishers after the first must tolerate duplicates.
+type MultiPublisher []EventPublisher
+
+// Publish implements EventPublisher.
+func (m MultiPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// WriterPublisher writes each event as a JSON line, e.g. to a file for local development or replay.
+type WriterPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterPublisher creates a publisher writing JSON lines to w.
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewFilePublisher creates a publisher appending JSON lines to the file at path.
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterPublisher(file), nil
+}
+
+// Publish implements EventPublisher.
+func (p *WriterPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.w.Write(append(line, '\n'))
+	return err
+}
+
+// LogPublisher writes each event to the service log. Payloads hold personal data (email, date of
+// birth), so only the event's identity is logged.
+type LogPublisher struct{}
+
+// Publish implements EventPublisher.
+func (LogPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	log.Printf("✅ Event %d %s | UserID: %s", event.ID, event.EventType, event.UserID)
+	return nil
+}
@@ -3,18 +3,30 @@ package handlers
 import (
 	"context"
 
+	"google.golang.org/grpc"
+
 	"github.com/xIndustries/BandRoom/backend-auth/internal/services"
 	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
 )
 
 type UserHandler struct {
-	Service *services.UserService
+	Service       *services.UserService
+	Sessions      *services.SessionService
+	Denylist      *services.DenylistService
+	Watch         *services.WatchService
+	Webhooks      *services.WebhookService
+	Audit         *services.AuditService
+	Exports       *services.DataExportService
+	Consents      *services.ConsentService
+	Roles         *services.RoleService
+	Moderation    *services.ModerationService
+	Impersonation *services.ImpersonationService
 	pb.UnimplementedUserServiceServer
 }
 
 // NewUserHandler creates a new UserHandler instance.
-func NewUserHandler(service *services.UserService) *UserHandler {
-	return &UserHandler{Service: service}
+func NewUserHandler(service *services.UserService, sessions *services.SessionService, denylist *services.DenylistService, watch *services.WatchService, webhooks *services.WebhookService, audit *services.AuditService, exports *services.DataExportService, consents *services.ConsentService, roles *services.RoleService, moderation *services.ModerationService, impersonation *services.ImpersonationService) *UserHandler {
+	return &UserHandler{Service: service, Sessions: sessions, Denylist: denylist, Watch: watch, Webhooks: webhooks, Audit: audit, Exports: exports, Consents: consents, Roles: roles, Moderation: moderation, Impersonation: impersonation}
 }
 
 func (h *UserHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.UserResponse, error) {
@@ -25,6 +37,30 @@ func (h *UserHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 	return h.Service.GetUser(ctx, req)
 }
 
+func (h *UserHandler) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	return h.Service.BatchGetUsers(ctx, req)
+}
+
+func (h *UserHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	return h.Service.ListUsers(ctx, req)
+}
+
+func (h *UserHandler) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	return h.Service.SearchUsers(ctx, req)
+}
+
+func (h *UserHandler) ExportUsers(req *pb.ExportUsersRequest, stream grpc.ServerStreamingServer[pb.ExportUsersResponse]) error {
+	return h.Service.ExportUsers(req, stream)
+}
+
+func (h *UserHandler) ImportUsers(stream grpc.ClientStreamingServer[pb.ImportUsersRequest, pb.ImportUsersResponse]) error {
+	return h.Service.ImportUsers(stream)
+}
+
+func (h *UserHandler) WatchUsers(req *pb.WatchUsersRequest, stream grpc.ServerStreamingServer[pb.UserEvent]) error {
+	return h.Watch.WatchUsers(req, stream)
+}
+
 func (h *UserHandler) UpdateUsername(ctx context.Context, req *pb.UpdateUsernameRequest) (*pb.UserResponse, error) {
 	return h.Service.UpdateUsername(ctx, req)
 }
@@ -36,3 +72,131 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 func (h *UserHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
 	return h.Service.DeleteUser(ctx, req)
 }
+
+func (h *UserHandler) CheckUsernameAvailability(ctx context.Context, req *pb.CheckUsernameAvailabilityRequest) (*pb.CheckUsernameAvailabilityResponse, error) {
+	return h.Service.CheckUsernameAvailability(ctx, req)
+}
+
+func (h *UserHandler) RecordLogin(ctx context.Context, req *pb.RecordLoginRequest) (*pb.LoginEvent, error) {
+	return h.Service.RecordLogin(ctx, req)
+}
+
+func (h *UserHandler) ListLoginHistory(ctx context.Context, req *pb.ListLoginHistoryRequest) (*pb.ListLoginHistoryResponse, error) {
+	return h.Service.ListLoginHistory(ctx, req)
+}
+
+func (h *UserHandler) RegisterSession(ctx context.Context, req *pb.RegisterSessionRequest) (*pb.Session, error) {
+	return h.Sessions.RegisterSession(ctx, req)
+}
+
+func (h *UserHandler) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	return h.Sessions.ListSessions(ctx, req)
+}
+
+func (h *UserHandler) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionsResponse, error) {
+	return h.Sessions.RevokeSession(ctx, req)
+}
+
+func (h *UserHandler) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeSessionsResponse, error) {
+	return h.Sessions.RevokeAllSessions(ctx, req)
+}
+
+func (h *UserHandler) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	return h.Denylist.RevokeToken(ctx, req)
+}
+
+func (h *UserHandler) RevokeUserTokens(ctx context.Context, req *pb.RevokeUserTokensRequest) (*pb.RevokeUserTokensResponse, error) {
+	return h.Denylist.RevokeUserTokens(ctx, req)
+}
+
+func (h *UserHandler) CreateWebhookSubscription(ctx context.Context, req *pb.CreateWebhookSubscriptionRequest) (*pb.WebhookSubscription, error) {
+	return h.Webhooks.CreateWebhookSubscription(ctx, req)
+}
+
+func (h *UserHandler) ListWebhookSubscriptions(ctx context.Context, req *pb.ListWebhookSubscriptionsRequest) (*pb.ListWebhookSubscriptionsResponse, error) {
+	return h.Webhooks.ListWebhookSubscriptions(ctx, req)
+}
+
+func (h *UserHandler) UpdateWebhookSubscription(ctx context.Context, req *pb.UpdateWebhookSubscriptionRequest) (*pb.WebhookSubscription, error) {
+	return h.Webhooks.UpdateWebhookSubscription(ctx, req)
+}
+
+func (h *UserHandler) DeleteWebhookSubscription(ctx context.Context, req *pb.DeleteWebhookSubscriptionRequest) (*pb.DeleteWebhookSubscriptionResponse, error) {
+	return h.Webhooks.DeleteWebhookSubscription(ctx, req)
+}
+
+func (h *UserHandler) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	return h.Webhooks.ListWebhookDeliveries(ctx, req)
+}
+
+func (h *UserHandler) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.WebhookDelivery, error) {
+	return h.Webhooks.RedeliverWebhook(ctx, req)
+}
+
+func (h *UserHandler) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	return h.Audit.ListAuditEvents(ctx, req)
+}
+
+func (h *UserHandler) VerifyAuditLog(ctx context.Context, req *pb.VerifyAuditLogRequest) (*pb.VerifyAuditLogResponse, error) {
+	return h.Audit.VerifyAuditLog(ctx, req)
+}
+
+func (h *UserHandler) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	return h.Exports.ExportMyData(ctx, req)
+}
+
+func (h *UserHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.DataExport, error) {
+	return h.Exports.ExportUserData(ctx, req)
+}
+
+func (h *UserHandler) GetDataExport(ctx context.Context, req *pb.GetDataExportRequest) (*pb.DataExport, error) {
+	return h.Exports.GetDataExport(ctx, req)
+}
+
+func (h *UserHandler) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.ErasureCertificate, error) {
+	return h.Service.EraseUser(ctx, req)
+}
+
+func (h *UserHandler) GetErasureCertificate(ctx context.Context, req *pb.GetErasureCertificateRequest) (*pb.ErasureCertificate, error) {
+	return h.Service.GetErasureCertificate(ctx, req)
+}
+
+func (h *UserHandler) PublishLegalDocument(ctx context.Context, req *pb.PublishLegalDocumentRequest) (*pb.LegalDocument, error) {
+	return h.Consents.PublishLegalDocument(ctx, req)
+}
+
+func (h *UserHandler) AcceptTerms(ctx context.Context, req *pb.AcceptTermsRequest) (*pb.Consents, error) {
+	return h.Consents.AcceptTerms(ctx, req)
+}
+
+func (h *UserHandler) GetConsents(ctx context.Context, req *pb.GetConsentsRequest) (*pb.Consents, error) {
+	return h.Consents.GetConsents(ctx, req)
+}
+
+func (h *UserHandler) UpdateMarketingPreferences(ctx context.Context, req *pb.UpdateMarketingPreferencesRequest) (*pb.Consents, error) {
+	return h.Consents.UpdateMarketingPreferences(ctx, req)
+}
+
+func (h *UserHandler) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.UserRole, error) {
+	return h.Roles.AssignRole(ctx, req)
+}
+
+func (h *UserHandler) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	return h.Roles.RevokeRole(ctx, req)
+}
+
+func (h *UserHandler) ListUserRoles(ctx context.Context, req *pb.ListUserRolesRequest) (*pb.ListUserRolesResponse, error) {
+	return h.Roles.ListUserRoles(ctx, req)
+}
+
+func (h *UserHandler) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.UserResponse, error) {
+	return h.Moderation.SuspendUser(ctx, req)
+}
+
+func (h *UserHandler) UnsuspendUser(ctx context.Context, req *pb.UnsuspendUserRequest) (*pb.UserResponse, error) {
+	return h.Moderation.UnsuspendUser(ctx, req)
+}
+
+func (h *UserHandler) Impersonate(ctx context.Context, req *pb.ImpersonateRequest) (*pb.ImpersonateResponse, error) {
+	return h.Impersonation.Impersonate(ctx, req)
+}
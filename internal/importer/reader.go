@@ -0,0 +1,151 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// Format is the encoding of an import file.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"   // Header row naming auth0_id, email and (optionally) username columns
+	FormatJSONL Format = "jsonl" // One JSON object per line with auth0_id, email and username keys
+)
+
+// maxLineSize bounds a single JSONL line.
+const maxLineSize = 1 << 20
+
+// ParseFormat validates a format name.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSONL, "ndjson":
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("unsupported import format %q (use csv or jsonl)", name)
+	}
+}
+
+// DetectFormat infers the format from a file extension.
+func DetectFormat(filename string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(filename), "."))
+}
+
+// Record is a single row read from an import file.
+type Record struct {
+	Line  int                    // 1-based line (JSONL) or record number including the header (CSV)
+	Input models.CreateUserInput // Parsed row, empty when Err is set
+	Err   error                  // Set when the row could not be parsed
+}
+
+// Reader reads user records from CSV or JSONL input.
+type Reader struct {
+	next func() (*Record, error)
+}
+
+// NewReader creates a Reader for the format. For CSV the header row is read immediately.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatJSONL:
+		return newJSONLReader(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+// Next returns the next record, or io.EOF when the input is exhausted. Malformed rows are
+// returned as records with Err set; a non-nil error means the input itself is unreadable.
+func (r *Reader) Next() (*Record, error) {
+	return r.next()
+}
+
+func newCSVReader(r io.Reader) (*Reader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := map[string]int{"auth0_id": -1, "email": -1, "username": -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, known := columns[name]; known {
+			columns[name] = i
+		}
+	}
+	if columns["auth0_id"] < 0 || columns["email"] < 0 {
+		return nil, errors.New("CSV header must include auth0_id and email columns")
+	}
+
+	field := func(row []string, name string) string {
+		if i := columns[name]; i >= 0 && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	line := 1
+	return &Reader{next: func() (*Record, error) {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		line++
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return &Record{Line: line, Err: parseErr.Err}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &Record{Line: line, Input: models.CreateUserInput{
+			Auth0ID:  field(row, "auth0_id"),
+			Email:    field(row, "email"),
+			Username: field(row, "username"),
+		}}, nil
+	}}, nil
+}
+
+func newJSONLReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	line := 0
+	return &Reader{next: func() (*Record, error) {
+		for scanner.Scan() {
+			line++
+			data := bytes.TrimSpace(scanner.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+
+			var input models.CreateUserInput
+			if err := json.Unmarshal(data, &input); err != nil {
+				return &Record{Line: line, Err: fmt.Errorf("invalid JSON: %w", err)}, nil
+			}
+			return &Record{Line: line, Input: input}, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}}
+}
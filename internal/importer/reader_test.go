@@ -0,0 +1,116 @@
+package importer
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr bool
+	}{
+		{name: "csv", want: FormatCSV},
+		{name: "CSV", want: FormatCSV},
+		{name: "jsonl", want: FormatJSONL},
+		{name: "ndjson", want: FormatJSONL},
+		{name: "json", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q (error: %v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     Format
+		wantErr  bool
+	}{
+		{filename: "users.csv", want: FormatCSV},
+		{filename: "exports/users.JSONL", want: FormatJSONL},
+		{filename: "users.txt", wantErr: true},
+		{filename: "users", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := DetectFormat(tt.filename)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("DetectFormat(%q) = %q, %v, want %q (error: %v)", tt.filename, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNewReaderRejectsBadCSVHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ""},
+		{"missing email", "auth0_id,username\n"},
+		{"missing auth0_id", "email,username\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReader(strings.NewReader(tt.input), FormatCSV); err == nil {
+				t.Errorf("NewReader(%q) succeeded, want error", tt.input)
+			}
+		})
+	}
+}
+
+func TestReaderRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []Record
+	}{
+		{
+			name:   "csv",
+			format: FormatCSV,
+			input:  "\ufeffEmail, auth0_id ,username\n jane@example.com ,auth0|jane,jane_doe\njohn@example.com,auth0|john\n\"broken,auth0|x\n",
+			want: []Record{
+				{Line: 2, Input: models.CreateUserInput{Auth0ID: "auth0|jane", Email: "jane@example.com", Username: "jane_doe"}},
+				{Line: 3, Input: models.CreateUserInput{Auth0ID: "auth0|john", Email: "john@example.com"}},
+				{Line: 4, Err: io.ErrUnexpectedEOF},
+			},
+		},
+		{
+			name:   "jsonl",
+			format: FormatJSONL,
+			input:  "{\"auth0_id\":\"auth0|jane\",\"email\":\"jane@example.com\"}\n\n  \n{\"auth0_id\":\n",
+			want: []Record{
+				{Line: 1, Input: models.CreateUserInput{Auth0ID: "auth0|jane", Email: "jane@example.com"}},
+				{Line: 4, Err: io.ErrUnexpectedEOF},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewReader(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatalf("NewReader() = %v", err)
+			}
+			for i, want := range tt.want {
+				got, err := reader.Next()
+				if err != nil {
+					t.Fatalf("Next() #%d = %v", i, err)
+				}
+				if got.Line != want.Line || got.Input != want.Input || (got.Err != nil) != (want.Err != nil) {
+					t.Errorf("Next() #%d = %+v, want %+v", i, *got, want)
+				}
+			}
+			if _, err := reader.Next(); err != io.EOF {
+				t.Errorf("Next() after the last record = %v, want io.EOF", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+)
+
+// RevocationChecker reports whether a verified token has been revoked before its expiry.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims *auth.Claims) (bool, error)
+}
+
+// PermissionSource supplies permissions granted to a subject outside their token, e.g. by local roles.
+type PermissionSource interface {
+	Permissions(ctx context.Context, subject string) ([]string, error)
+}
+
+// AccountStatusChecker reports why a subject's account may not be used (e.g. it is suspended),
+// or "" when it may.
+type AccountStatusChecker interface {
+	AccountRestriction(ctx context.Context, subject string) (string, error)
+}
+
+// ImpersonationResolver resolves an impersonation token presented by an authenticated staff member
+// to the claims the call is made with.
+type ImpersonationResolver interface {
+	ResolveImpersonation(ctx context.Context, method, token string, actor *auth.Claims) (*auth.Claims, error)
+}
+
+// AuthInterceptor verifies Auth0 bearer tokens and attaches the caller's claims to the context.
+type AuthInterceptor struct {
+	verifier       *auth.Verifier
+	required       bool
+	permissions    PermissionSource
+	accounts       AccountStatusChecker
+	impersonations ImpersonationResolver
+	revocations    []RevocationChecker
+}
+
+// NewAuthInterceptor creates an AuthInterceptor. When required is false, calls without an
+// authorization header are let through unauthenticated; a present but invalid or revoked
+// token is always rejected, as is a token of a suspended or banned account. Permissions from the
+// source, if any, are added to the token's. Calls carrying an impersonation token are made as the
+// impersonated user when the resolver accepts it.
+func NewAuthInterceptor(verifier *auth.Verifier, required bool, permissions PermissionSource, accounts AccountStatusChecker, impersonations ImpersonationResolver, revocations ...RevocationChecker) *AuthInterceptor {
+	return &AuthInterceptor{
+		verifier:       verifier,
+		required:       required,
+		permissions:    permissions,
+		accounts:       accounts,
+		impersonations: impersonations,
+		revocations:    revocations,
+	}
+}
+
+// Unary returns the unary server interceptor.
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the streaming server interceptor.
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if isPublicMethod(method) {
+		return ctx, nil
+	}
+
+	token := bearerToken(ctx)
+	if token == "" {
+		if impersonationToken(ctx) != "" {
+			return nil, status.Error(codes.Unauthenticated, "impersonation requires a bearer token")
+		}
+		if i.required {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		return ctx, nil
+	}
+	if i.verifier == nil {
+		return nil, status.Error(codes.Unauthenticated, "token verification is not configured")
+	}
+
+	claims, err := i.verifier.Verify(token)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to verify token: %v", err)
+	}
+
+	for _, checker := range i.revocations {
+		revoked, err := checker.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to check token revocation: %v", err)
+		}
+		if revoked {
+			return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+		}
+	}
+
+	if err := i.checkAccount(ctx, claims.Subject); err != nil {
+		return nil, err
+	}
+
+	if i.permissions != nil {
+		granted, err := i.permissions.Permissions(ctx, claims.Subject)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to load permissions: %v", err)
+		}
+		claims.Permissions = append(slices.Clip(claims.Permissions), granted...)
+	}
+
+	if grant := impersonationToken(ctx); grant != "" {
+		if i.impersonations == nil {
+			return nil, status.Error(codes.Unauthenticated, "impersonation is not enabled")
+		}
+		claims, err = i.impersonations.ResolveImpersonation(ctx, method, grant, claims)
+		if err != nil {
+			return nil, err
+		}
+		// The impersonated user's restrictions apply as well, so staff see what they see.
+		if err := i.checkAccount(ctx, claims.Subject); err != nil {
+			return nil, err
+		}
+	}
+
+	return auth.NewContext(ctx, claims), nil
+}
+
+// checkAccount rejects subjects whose account is suspended or banned.
+func (i *AuthInterceptor) checkAccount(ctx context.Context, subject string) error {
+	if i.accounts == nil {
+		return nil
+	}
+	restriction, err := i.accounts.AccountRestriction(ctx, subject)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to check account status: %v", err)
+	}
+	if restriction != "" {
+		return status.Error(codes.PermissionDenied, restriction)
+	}
+	return nil
+}
+
+// isPublicMethod reports whether the method is served without authentication.
+func isPublicMethod(method string) bool {
+	return strings.HasPrefix(method, "/grpc.reflection.") || strings.HasPrefix(method, "/grpc.health.")
+}
+
+// bearerToken extracts the token from the "authorization: Bearer <token>" metadata header.
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if scheme, token, found := strings.Cut(value, " "); found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// impersonationToken extracts the impersonation token from its metadata header.
+func impersonationToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(auth.ImpersonationHeader); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,69 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{"missing", nil, ""},
+		{"bearer", []string{"Bearer abc.def.ghi"}, "abc.def.ghi"},
+		{"scheme in another case", []string{"bearer abc.def.ghi"}, "abc.def.ghi"},
+		{"other scheme", []string{"Basic dXNlcjpwYXNz"}, ""},
+		{"no token", []string{"Bearer"}, ""},
+		{"second header", []string{"Basic dXNlcjpwYXNz", "Bearer abc.def.ghi"}, "abc.def.ghi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			for _, value := range tt.values {
+				md.Append("authorization", value)
+			}
+			if got := bearerToken(metadata.NewIncomingContext(context.Background(), md)); got != tt.want {
+				t.Errorf("bearerToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutVerifiedToken(t *testing.T) {
+	withToken := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc.def.ghi"))
+	impersonationOnly := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.ImpersonationHeader, "grant"))
+
+	tests := []struct {
+		name     string
+		required bool
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{"public method", true, context.Background(), "/grpc.health.v1.Health/Check", codes.OK},
+		{"optional token missing", false, context.Background(), "/user.UserService/GetUser", codes.OK},
+		{"required token missing", true, context.Background(), "/user.UserService/GetUser", codes.Unauthenticated},
+		{"token without a verifier", false, withToken, "/user.UserService/GetUser", codes.Unauthenticated},
+		{"impersonation without a bearer token", false, impersonationOnly, "/user.UserService/GetUser", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewAuthInterceptor(nil, tt.required, nil, nil, nil)
+			ctx, err := interceptor.authenticate(tt.ctx, tt.method)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("authenticate() error = %v, want %v", err, tt.wantCode)
+			}
+			if err == nil && auth.FromContext(ctx) != nil {
+				t.Errorf("authenticate() attached claims %+v without a verified token", auth.FromContext(ctx))
+			}
+		})
+	}
+}
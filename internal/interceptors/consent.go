@@ -0,0 +1,72 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+)
+
+// ConsentChecker reports whether a caller has accepted the current versions of the legal documents.
+type ConsentChecker interface {
+	HasAcceptedCurrentTerms(ctx context.Context, subject string) (bool, error)
+}
+
+// ConsentInterceptor rejects calls from users who have not accepted the current terms, except for
+// exempt methods (such as AcceptTerms itself). It must run after the AuthInterceptor.
+type ConsentInterceptor struct {
+	checker ConsentChecker
+	exempt  map[string]bool
+}
+
+// NewConsentInterceptor creates a ConsentInterceptor; exempt lists full method names that are always allowed.
+// Unauthenticated calls and admins are not checked.
+func NewConsentInterceptor(checker ConsentChecker, exempt ...string) *ConsentInterceptor {
+	i := &ConsentInterceptor{checker: checker, exempt: make(map[string]bool, len(exempt))}
+	for _, method := range exempt {
+		i.exempt[method] = true
+	}
+	return i
+}
+
+// Unary returns the unary server interceptor.
+func (i *ConsentInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the streaming server interceptor.
+func (i *ConsentInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (i *ConsentInterceptor) check(ctx context.Context, method string) error {
+	if isPublicMethod(method) || i.exempt[method] {
+		return nil
+	}
+	claims := auth.FromContext(ctx)
+	if claims == nil || claims.HasPermission(auth.PermissionAdmin) {
+		return nil
+	}
+
+	accepted, err := i.checker.HasAcceptedCurrentTerms(ctx, claims.Subject)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to check terms acceptance: %v", err)
+	}
+	if !accepted {
+		return status.Error(codes.FailedPrecondition, "the current terms of service and privacy policy must be accepted (AcceptTerms)")
+	}
+	return nil
+}
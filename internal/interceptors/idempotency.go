@@ -0,0 +1,169 @@
+package interceptors
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+const (
+	// IdempotencyKeyHeader is the metadata header clients set to make a mutating call safe to retry.
+	IdempotencyKeyHeader = "idempotency-key"
+	// IdempotentReplayHeader is set to "true" on responses replayed from an earlier call.
+	IdempotentReplayHeader = "idempotent-replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyStore persists responses by idempotency key.
+type IdempotencyStore interface {
+	// Claim takes ownership of the key, or returns the record of the request that already used it.
+	Claim(ctx context.Context, scope, key, method string, requestHash []byte) (*models.IdempotencyKey, error)
+	// Complete stores the serialized response of a claimed key.
+	Complete(ctx context.Context, scope, key string, response []byte) error
+	// Release gives up a claimed key after the request failed.
+	Release(ctx context.Context, scope, key string) error
+}
+
+// IdempotencyInterceptor replays the original response when a unary call is retried with the same
+// idempotency key. Keys are scoped to the authenticated caller, so it must run after the AuthInterceptor.
+type IdempotencyInterceptor struct {
+	store   IdempotencyStore
+	methods map[string]bool
+}
+
+// NewIdempotencyInterceptor creates an IdempotencyInterceptor for the given full method names.
+// Calls to other methods, and calls without the header, are passed through.
+func NewIdempotencyInterceptor(store IdempotencyStore, methods ...string) *IdempotencyInterceptor {
+	i := &IdempotencyInterceptor{store: store, methods: make(map[string]bool, len(methods))}
+	for _, method := range methods {
+		i.methods[method] = true
+	}
+	return i
+}
+
+// Unary returns the unary server interceptor.
+func (i *IdempotencyInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !i.methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		key := idempotencyKey(ctx)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength)
+		}
+
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		hash, err := requestHash(info.FullMethod, message)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash request: %v", err)
+		}
+
+		scope := ""
+		if claims := auth.FromContext(ctx); claims != nil {
+			scope = claims.Subject
+		}
+
+		existing, err := i.store.Claim(ctx, scope, key, info.FullMethod, hash)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to check idempotency key: %v", err)
+		}
+		if existing != nil {
+			return replay(ctx, existing, hash)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			if releaseErr := i.store.Release(ctx, scope, key); releaseErr != nil {
+				log.Printf("❌ Failed to release idempotency key: %v", releaseErr)
+			}
+			return resp, err
+		}
+
+		if err := i.complete(ctx, scope, key, resp); err != nil {
+			// The mutation already happened; a retry will wait for the stale claim to lapse and run again.
+			log.Printf("❌ Failed to store idempotent response: %v", err)
+		}
+		return resp, nil
+	}
+}
+
+func (i *IdempotencyInterceptor) complete(ctx context.Context, scope, key string, resp interface{}) error {
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "response %T is not a protobuf message", resp)
+	}
+	packed, err := anypb.New(message)
+	if err != nil {
+		return err
+	}
+	serialized, err := proto.Marshal(packed)
+	if err != nil {
+		return err
+	}
+	return i.store.Complete(ctx, scope, key, serialized)
+}
+
+// replay returns the stored response of an earlier call with the same key.
+func replay(ctx context.Context, existing *models.IdempotencyKey, hash []byte) (interface{}, error) {
+	if !bytes.Equal(existing.RequestHash, hash) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s was already used for a different request", IdempotencyKeyHeader)
+	}
+	if existing.Response == nil {
+		return nil, status.Errorf(codes.Aborted, "a request with this %s is still in progress", IdempotencyKeyHeader)
+	}
+
+	packed := &anypb.Any{}
+	if err := proto.Unmarshal(existing.Response, packed); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+	resp, err := packed.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayHeader, "true"))
+	return resp, nil
+}
+
+// idempotencyKey returns the caller's idempotency key header, if any.
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(IdempotencyKeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// requestHash fingerprints the method and request so a reused key with a different payload is detected.
+func requestHash(method string, req proto.Message) ([]byte, error) {
+	serialized, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write(serialized)
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,143 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+const createUserMethod = "/user.UserService/CreateUser"
+
+// memoryIdempotencyStore is an IdempotencyStore backed by a map.
+type memoryIdempotencyStore struct {
+	keys     map[string]*models.IdempotencyKey
+	claimErr error
+	released int
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{keys: make(map[string]*models.IdempotencyKey)}
+}
+
+func (m *memoryIdempotencyStore) Claim(ctx context.Context, scope, key, method string, requestHash []byte) (*models.IdempotencyKey, error) {
+	if m.claimErr != nil {
+		return nil, m.claimErr
+	}
+	if existing, ok := m.keys[scope+"\x00"+key]; ok {
+		return existing, nil
+	}
+	m.keys[scope+"\x00"+key] = &models.IdempotencyKey{Scope: scope, Key: key, Method: method, RequestHash: requestHash}
+	return nil, nil
+}
+
+func (m *memoryIdempotencyStore) Complete(ctx context.Context, scope, key string, response []byte) error {
+	m.keys[scope+"\x00"+key].Response = response
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(ctx context.Context, scope, key string) error {
+	delete(m.keys, scope+"\x00"+key)
+	m.released++
+	return nil
+}
+
+// idempotentCall invokes the interceptor as subject with the idempotency key, counting handler runs.
+func idempotentCall(i *IdempotencyInterceptor, subject, key, method string, req *pb.CreateUserRequest, handlerErr error, runs *int) (interface{}, error) {
+	ctx := auth.NewContext(context.Background(), &auth.Claims{Subject: subject})
+	if key != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, key))
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		*runs++
+		if handlerErr != nil {
+			return nil, handlerErr
+		}
+		return &pb.UserResponse{Auth0Id: req.(*pb.CreateUserRequest).Auth0Id}, nil
+	}
+	return i.Unary()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+}
+
+func TestIdempotencyInterceptorReplaysRetries(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	i := NewIdempotencyInterceptor(store, createUserMethod)
+	req := &pb.CreateUserRequest{Auth0Id: "auth0|jane", Email: "jane@example.com"}
+	runs := 0
+
+	first, err := idempotentCall(i, "auth0|jane", "key-1", createUserMethod, req, nil, &runs)
+	if err != nil {
+		t.Fatalf("first call = %v", err)
+	}
+	retry, err := idempotentCall(i, "auth0|jane", "key-1", createUserMethod, req, nil, &runs)
+	if err != nil {
+		t.Fatalf("retry = %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("handler ran %d times, want 1", runs)
+	}
+	if !proto.Equal(first.(proto.Message), retry.(proto.Message)) {
+		t.Errorf("retry = %v, want the original response %v", retry, first)
+	}
+
+	if _, err := idempotentCall(i, "auth0|john", "key-1", createUserMethod, req, nil, &runs); err != nil || runs != 2 {
+		t.Errorf("same key from another caller = %v after %d runs, want a fresh call", err, runs)
+	}
+
+	other := &pb.CreateUserRequest{Auth0Id: "auth0|jane", Email: "jane@example.org"}
+	if _, err := idempotentCall(i, "auth0|jane", "key-1", createUserMethod, other, nil, &runs); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("key reused for another request error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	req := &pb.CreateUserRequest{Auth0Id: "auth0|jane", Email: "jane@example.com"}
+
+	tests := []struct {
+		name         string
+		method       string
+		key          string
+		inProgress   bool
+		claimErr     error
+		handlerErr   error
+		wantCode     codes.Code
+		wantRuns     int
+		wantReleased int
+	}{
+		{name: "method not covered", method: "/user.UserService/GetUser", key: "key-1", wantRuns: 1},
+		{name: "without a key", method: createUserMethod, wantRuns: 1},
+		{name: "key too long", method: createUserMethod, key: strings.Repeat("k", maxIdempotencyKeyLength+1), wantCode: codes.InvalidArgument},
+		{name: "first call still running", method: createUserMethod, key: "key-1", inProgress: true, wantCode: codes.Aborted},
+		{name: "store unavailable", method: createUserMethod, key: "key-1", claimErr: errors.New("connection refused"), wantCode: codes.Unavailable},
+		{name: "failed call releases the key", method: createUserMethod, key: "key-1", handlerErr: status.Error(codes.AlreadyExists, "email taken"), wantCode: codes.AlreadyExists, wantRuns: 1, wantReleased: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryIdempotencyStore()
+			store.claimErr = tt.claimErr
+			i := NewIdempotencyInterceptor(store, createUserMethod)
+			if tt.inProgress {
+				hash, _ := requestHash(tt.method, req)
+				store.keys["auth0|jane\x00"+tt.key] = &models.IdempotencyKey{RequestHash: hash}
+			}
+
+			runs := 0
+			_, err := idempotentCall(i, "auth0|jane", tt.key, tt.method, req, tt.handlerErr, &runs)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+			if runs != tt.wantRuns || store.released != tt.wantReleased {
+				t.Errorf("handler ran %d times and released %d keys, want %d and %d", runs, store.released, tt.wantRuns, tt.wantReleased)
+			}
+		})
+	}
+}
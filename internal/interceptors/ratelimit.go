@@ -0,0 +1,105 @@
+package interceptors
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/ratelimit"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+)
+
+// RetryAfterHeader carries the number of seconds a rate-limited caller should wait.
+const RetryAfterHeader = "retry-after"
+
+// RateLimitInterceptor throttles each RPC per authenticated subject and per client IP.
+// It must run after the AuthInterceptor so the caller's claims are available.
+type RateLimitInterceptor struct {
+	limiter ratelimit.Limiter
+	policy  *ratelimit.Policy
+}
+
+// NewRateLimitInterceptor creates a RateLimitInterceptor.
+func NewRateLimitInterceptor(limiter ratelimit.Limiter, policy *ratelimit.Policy) *RateLimitInterceptor {
+	return &RateLimitInterceptor{limiter: limiter, policy: policy}
+}
+
+// Unary returns the unary server interceptor.
+func (i *RateLimitInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if retryAfter, limited := i.check(ctx, info.FullMethod); limited {
+			_ = grpc.SetHeader(ctx, retryAfterMetadata(retryAfter))
+			return nil, exhausted(retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream returns the streaming server interceptor. Streams are limited when opened, not per message.
+func (i *RateLimitInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if retryAfter, limited := i.check(ss.Context(), info.FullMethod); limited {
+			_ = ss.SetHeader(retryAfterMetadata(retryAfter))
+			return exhausted(retryAfter)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// check takes a token from the caller's subject and IP buckets for the method. A failing
+// backend lets the call through rather than taking the service down with it.
+func (i *RateLimitInterceptor) check(ctx context.Context, method string) (time.Duration, bool) {
+	if isPublicMethod(method) {
+		return 0, false
+	}
+	limit := i.policy.For(method)
+	if limit.Unlimited() {
+		return 0, false
+	}
+	if limit.Blocked() {
+		log.Printf("❌ Rate limit blocks method | Method: %s", method)
+		return limit.Per, true
+	}
+
+	var keys []string
+	if claims := auth.FromContext(ctx); claims != nil {
+		keys = append(keys, "sub:"+claims.Subject+":"+method)
+	}
+	if ip := utils.ClientIP(ctx); ip != "" {
+		keys = append(keys, "ip:"+ip+":"+method)
+	}
+
+	for _, key := range keys {
+		allowed, retryAfter, err := i.limiter.Allow(ctx, key, limit)
+		if err != nil {
+			log.Printf("❌ Rate limiter unavailable, allowing call: %v", err)
+			return 0, false
+		}
+		if !allowed {
+			log.Printf("❌ Rate limit exceeded | Key: %s | Limit: %s", key, limit)
+			return retryAfter, true
+		}
+	}
+	return 0, false
+}
+
+// retryAfterSeconds rounds the wait up to whole seconds, never below one.
+func retryAfterSeconds(retryAfter time.Duration) int {
+	return max(1, int(math.Ceil(retryAfter.Seconds())))
+}
+
+func retryAfterMetadata(retryAfter time.Duration) metadata.MD {
+	return metadata.Pairs(RetryAfterHeader, strconv.Itoa(retryAfterSeconds(retryAfter)))
+}
+
+func exhausted(retryAfter time.Duration) error {
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %ds", retryAfterSeconds(retryAfter))
+}
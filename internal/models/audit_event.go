@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audited actions.
+const (
+	AuditUserCreated           = "user.created"
+	AuditUserUpdated           = "user.updated"
+	AuditUserUsernameChanged   = "user.username_changed"
+	AuditUserDeleted           = "user.deleted"
+	AuditUserImported          = "user.imported"
+	AuditUserErased            = "user.erased"
+	AuditUserSuspended         = "user.suspended"
+	AuditUserBanned            = "user.banned"
+	AuditUserUnsuspended       = "user.unsuspended"
+	AuditUserSuspensionExpired = "user.suspension_expired"
+	AuditSessionRevoked        = "session.revoked"
+	AuditTokenRevoked          = "token.revoked"
+	AuditUserTokensRevoked     = "user_tokens.revoked"
+	AuditWebhookCreated        = "webhook.created"
+	AuditWebhookUpdated        = "webhook.updated"
+	AuditWebhookDeleted        = "webhook.deleted"
+	AuditWebhookRedelivered    = "webhook.redelivered"
+	AuditDataExportRequested   = "data_export.requested"
+	AuditConsentUpdated        = "consent.updated"
+	AuditDocumentPublished     = "legal_document.published"
+	AuditRoleAssigned          = "role.assigned"
+	AuditRoleRevoked           = "role.revoked"
+	AuditImpersonationStarted  = "impersonation.started"
+	AuditImpersonatedCall      = "impersonation.call"
+)
+
+// AuditEvent represents an entry in the append-only audit_log table.
+type AuditEvent struct {
+	ID                  int64           `json:"id" db:"id"`
+	OccurredAt          time.Time       `json:"occurred_at" db:"occurred_at"`                             // UTC, microsecond precision
+	ActorSubject        *string         `json:"actor_subject,omitempty" db:"actor_subject"`               // Caller's Auth0 ID
+	ImpersonatorSubject *string         `json:"impersonator_subject,omitempty" db:"impersonator_subject"` // Staff member acting as the caller
+	Action              string          `json:"action" db:"action"`                                       // e.g. user.created
+	RPC                 *string         `json:"rpc,omitempty" db:"rpc"`                                   // Full gRPC method name
+	TargetUserID        *string         `json:"target_user_id,omitempty" db:"target_user_id"`             // Affected user (UUID)
+	TargetAuth0ID       *string         `json:"target_auth0_id,omitempty" db:"target_auth0_id"`           // Affected user's Auth0 ID
+	TargetAuth0IDSalt   []byte          `json:"-" db:"target_auth0_id_salt"`                              // Random salt of the digest, nulled with the value
+	TargetAuth0IDDigest []byte          `json:"-" db:"target_auth0_id_digest"`                            // SHA-256 of salt and TargetAuth0ID
+	RequestID           *string         `json:"request_id,omitempty" db:"request_id"`                     // Caller's x-request-id
+	ClientIP            *string         `json:"client_ip,omitempty" db:"client_ip"`                       // Caller's IP address
+	ClientIPSalt        []byte          `json:"-" db:"client_ip_salt"`                                    // Random salt of the digest, nulled with the value
+	ClientIPDigest      []byte          `json:"-" db:"client_ip_digest"`                                  // SHA-256 of salt and ClientIP
+	Changes             json.RawMessage `json:"changes,omitempty" db:"changes"`                           // map[string]AuditChange
+	ChangesDigest       []byte          `json:"-" db:"changes_digest"`                                    // SHA-256 of Changes
+	PrevHash            []byte          `json:"-" db:"prev_hash"`                                         // Hash of the previous entry
+	Hash                []byte          `json:"-" db:"hash"`                                              // Hash of this entry
+	RedactedAt          *time.Time      `json:"redacted_at,omitempty" db:"redacted_at"`                   // Set once Changes was erased
+}
+
+// AuditChange is the old and new value of one field. A nil side means the field was unset.
+type AuditChange struct {
+	Old *string `json:"old,omitempty"`
+	New *string `json:"new,omitempty"`
+}
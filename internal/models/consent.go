@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// Legal document kinds users must accept.
+const (
+	DocumentTerms   = "terms"
+	DocumentPrivacy = "privacy"
+)
+
+// Marketing channels users opt in to or out of.
+const (
+	ConsentMarketingEmail = "marketing_email"
+	ConsentMarketingPush  = "marketing_push"
+)
+
+// LegalDocument represents a published version of the terms or privacy policy.
+type LegalDocument struct {
+	Kind        string    `json:"kind" db:"kind"`                       // terms, privacy
+	Version     string    `json:"version" db:"version"`                 // Publisher-chosen version label
+	URL         string    `json:"url" db:"url"`                         // Where the text is published
+	EffectiveAt time.Time `json:"effective_at" db:"effective_at"`       // When it becomes current
+	CreatedBy   *string   `json:"created_by,omitempty" db:"created_by"` // Admin who published it
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// ConsentDecision represents a row of the user_consents table.
+type ConsentDecision struct {
+	ID        int64     `json:"id" db:"id"`
+	UserID    string    `json:"user_id" db:"user_id"`                 // Deciding user (UUID)
+	Kind      string    `json:"kind" db:"kind"`                       // A document kind or marketing channel
+	Version   *string   `json:"version,omitempty" db:"version"`       // Accepted document version
+	Granted   bool      `json:"granted" db:"granted"`                 // Opted in (always true for documents)
+	Source    string    `json:"source" db:"source"`                   // e.g. ios_signup, web_settings
+	IPAddress *string   `json:"ip_address,omitempty" db:"ip_address"` // Client IP address
+	UserAgent *string   `json:"user_agent,omitempty" db:"user_agent"` // Client user agent
+	DecidedAt time.Time `json:"decided_at" db:"decided_at"`
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// Data export job statuses.
+const (
+	DataExportPending   = "pending"
+	DataExportRunning   = "running"
+	DataExportSucceeded = "succeeded"
+	DataExportFailed    = "failed"
+)
+
+// DataExportFormat and DataExportVersion identify the archive layout. The version is bumped
+// whenever a field is removed or changes meaning; new fields may be added without a bump.
+const (
+	DataExportFormat  = "bandroom.user-data-export"
+	DataExportVersion = 1
+)
+
+// DataExport represents a personal data export job stored in the data_exports table.
+type DataExport struct {
+	ID          string     `json:"id" db:"id"`                               // Primary key (UUID)
+	UserID      string     `json:"user_id" db:"user_id"`                     // User whose data is exported (UUID)
+	RequestedBy *string    `json:"requested_by,omitempty" db:"requested_by"` // Caller's Auth0 ID
+	Status      string     `json:"status" db:"status"`                       // pending, running, succeeded, failed
+	Archive     []byte     `json:"-" db:"archive"`                           // DataExportArchive as JSON
+	Error       *string    `json:"error,omitempty" db:"error"`               // Why the export failed
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"` // When the job and archive are deleted
+}
+
+// DataExportArchive is the document handed to the user: every row the service holds about them.
+type DataExportArchive struct {
+	Format      string    `json:"format"`       // Always DataExportFormat
+	Version     int       `json:"version"`      // DataExportVersion
+	ExportID    string    `json:"export_id"`    // Job that produced the archive
+	GeneratedAt time.Time `json:"generated_at"` // Snapshot time of every section
+
+	User                 *User                  `json:"user"`
+	UsernameHistory      []*UsernameChange      `json:"username_history"`
+	UsernameReservations []*UsernameReservation `json:"username_reservations"`
+	LoginEvents          []*LoginEvent          `json:"login_events"`
+	Sessions             []*Session             `json:"sessions"`
+	Consents             []*ConsentDecision     `json:"consents"`
+	Roles                []*UserRole            `json:"user_roles"`
+	Impersonations       []*Impersonation       `json:"impersonations"`
+	RevokedTokens        []*RevokedToken        `json:"revoked_tokens"`
+	TokenRevocations     []*UserTokenRevocation `json:"token_revocations"`
+	IdempotencyKeys      []*IdempotencyKeyUsage `json:"idempotency_keys"`
+	Events               []*OutboxEvent         `json:"events"`
+	AuditLog             []*AuditEvent          `json:"audit_log"`
+	PreviousDataExports  []*DataExport          `json:"data_exports"`
+}
+
+// UsernameChange is a row of the username_history table.
+type UsernameChange struct {
+	OldUsername *string   `json:"old_username,omitempty"` // NULL when the username was first set
+	NewUsername string    `json:"new_username"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// UsernameReservation is a row of the username_reservations table.
+type UsernameReservation struct {
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IdempotencyKeyUsage describes a request made with an idempotency key, without the stored response.
+type IdempotencyKeyUsage struct {
+	Key       string    `json:"key"`
+	Method    string    `json:"method"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
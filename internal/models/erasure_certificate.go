@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// ErasureCertificate records that a user's personal data was erased, stored in the erasure_certificates table.
+type ErasureCertificate struct {
+	ID          string           `json:"id" db:"id"`                               // Primary key (UUID)
+	UserID      string           `json:"user_id" db:"user_id"`                     // Erased user (UUID)
+	RequestedBy *string          `json:"requested_by,omitempty" db:"requested_by"` // Admin who erased the user
+	Reason      *string          `json:"reason,omitempty" db:"reason"`             // e.g. a support ticket reference
+	Scrubbed    map[string]int64 `json:"scrubbed" db:"scrubbed"`                   // Rows scrubbed or deleted, keyed by table
+	Remaining   []string         `json:"remaining" db:"remaining"`                 // Known copies the erasure could not reach
+	CompletedAt time.Time        `json:"completed_at" db:"completed_at"`           // When the erasure committed
+}
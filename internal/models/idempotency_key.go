@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyKey records a mutating request made with an "idempotency-key" header.
+type IdempotencyKey struct {
+	Scope       string    `json:"scope" db:"scope"`               // Caller's Auth0 ID
+	Key         string    `json:"key" db:"key"`                   // Client-chosen key
+	Method      string    `json:"method" db:"method"`             // Full gRPC method name
+	RequestHash []byte    `json:"request_hash" db:"request_hash"` // SHA-256 of the request
+	Response    []byte    `json:"response" db:"response"`         // Serialized response, nil while in progress
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`     // When the key was first used
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"`     // When the key may be reused
+}
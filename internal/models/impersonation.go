@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// Impersonation represents a grant stored in the impersonations table, letting a staff member
+// act as a user until it expires.
+type Impersonation struct {
+	ID           string    `json:"id" db:"id"`                       // Primary key (UUID)
+	TokenHash    []byte    `json:"-" db:"token_hash"`                // SHA-256 of the token
+	ActorSubject string    `json:"actor_subject" db:"actor_subject"` // Auth0 ID of the staff member
+	UserID       string    `json:"user_id" db:"user_id"`             // Impersonated user (UUID)
+	Subject      string    `json:"subject" db:"-"`                   // Impersonated user's Auth0 ID
+	Reason       string    `json:"reason" db:"reason"`               // e.g. a support ticket reference
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// LoginEvent represents a single login recorded in the login_events table.
+type LoginEvent struct {
+	ID         int64     `json:"id" db:"id"`                             // Sequential event ID
+	UserID     string    `json:"user_id" db:"user_id"`                   // User who logged in (UUID)
+	OccurredAt time.Time `json:"occurred_at" db:"occurred_at"`           // When the login happened
+	IPAddress  *string   `json:"ip_address,omitempty" db:"ip_address"`   // Client IP address
+	UserAgent  *string   `json:"user_agent,omitempty" db:"user_agent"`   // Client user agent
+	AppVersion *string   `json:"app_version,omitempty" db:"app_version"` // Client app version
+	AuthMethod *string   `json:"auth_method,omitempty" db:"auth_method"` // Auth0 connection or strategy
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// User lifecycle event types written to the outbox.
+const (
+	EventUserCreated = "UserCreated"
+	EventUserUpdated = "UserUpdated"
+	EventUserDeleted = "UserDeleted"
+	EventUserErased  = "UserErased"
+)
+
+// OutboxEvent represents a domain event stored in the outbox_events table.
+type OutboxEvent struct {
+	ID          int64           `json:"id" db:"id"`                               // Monotonic position
+	EventType   string          `json:"type" db:"event_type"`                     // UserCreated, UserUpdated, UserDeleted
+	UserID      string          `json:"user_id" db:"user_id"`                     // User the event is about (UUID)
+	Payload     json.RawMessage `json:"payload" db:"payload"`                     // UserEventPayload as JSON
+	CreatedAt   time.Time       `json:"occurred_at" db:"created_at"`              // When the change was committed
+	PublishedAt *time.Time      `json:"published_at,omitempty" db:"published_at"` // When the relay published it
+	XactID      int64           `json:"-" db:"xact_id"`                           // Writing transaction, orders the change feed
+}
+
+// UserEventPayload is the body of a user lifecycle event.
+type UserEventPayload struct {
+	User          *User    `json:"user"`                     // Snapshot of the user after the change
+	ChangedFields []string `json:"changed_fields,omitempty"` // Fields that changed (UserUpdated only)
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// Role represents a local role stored in the roles table.
+type Role struct {
+	Name        string    `json:"name" db:"name"`                             // Primary key, e.g. support
+	Description *string   `json:"description,omitempty" db:"description"`     // What the role is for
+	Permissions []string  `json:"permissions" db:"permissions"`               // Permissions the role grants
+	Auth0RoleID *string   `json:"auth0_role_id,omitempty" db:"auth0_role_id"` // Auth0 role kept in sync
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// UserRole is a role assigned to a user, stored in the user_roles table.
+type UserRole struct {
+	Role
+	UserID    string    `json:"user_id" db:"user_id"`                 // Assigned user (UUID)
+	GrantedBy *string   `json:"granted_by,omitempty" db:"granted_by"` // Admin who assigned it
+	GrantedAt time.Time `json:"granted_at" db:"granted_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Session represents a device a user is signed in on, stored in the sessions table.
+type Session struct {
+	ID          string     `json:"id" db:"id"`                               // Primary key (UUID)
+	UserID      string     `json:"user_id" db:"user_id"`                     // Owning user (UUID)
+	DeviceID    string     `json:"device_id" db:"device_id"`                 // Stable per-install identifier
+	TokenFamily *string    `json:"token_family,omitempty" db:"token_family"` // Auth0 session ID ("sid")
+	DeviceName  *string    `json:"device_name,omitempty" db:"device_name"`   // Human-readable device name
+	Platform    *string    `json:"platform,omitempty" db:"platform"`         // ios, ipados, android, web
+	AppVersion  *string    `json:"app_version,omitempty" db:"app_version"`   // Client app version
+	IPAddress   *string    `json:"ip_address,omitempty" db:"ip_address"`     // Last seen client IP
+	UserAgent   *string    `json:"user_agent,omitempty" db:"user_agent"`     // Last seen user agent
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`               // When the session was registered
+	LastSeenAt  time.Time  `json:"last_seen_at" db:"last_seen_at"`           // When the session last registered activity
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`     // Set once the session is signed out
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// RevokedToken represents a single access token on the denylist.
+type RevokedToken struct {
+	JTI       string    `json:"jti" db:"jti"`                         // Token ID claim
+	Subject   *string   `json:"subject,omitempty" db:"subject"`       // Token subject (Auth0 ID)
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`           // Token expiry
+	Reason    *string   `json:"reason,omitempty" db:"reason"`         // Why it was revoked
+	RevokedBy *string   `json:"revoked_by,omitempty" db:"revoked_by"` // Admin who revoked it
+	RevokedAt time.Time `json:"revoked_at" db:"revoked_at"`           // When it was revoked
+}
+
+// UserTokenRevocation rejects every token of a subject issued at or before RevokedBefore.
+type UserTokenRevocation struct {
+	Subject       string    `json:"subject" db:"subject"`                 // Auth0 ID
+	RevokedBefore time.Time `json:"revoked_before" db:"revoked_before"`   // Issue-time cutoff
+	ExpiresAt     time.Time `json:"expires_at" db:"expires_at"`           // When the cutoff stops mattering
+	Reason        *string   `json:"reason,omitempty" db:"reason"`         // Why tokens were revoked
+	RevokedBy     *string   `json:"revoked_by,omitempty" db:"revoked_by"` // Admin who revoked them
+	RevokedAt     time.Time `json:"revoked_at" db:"revoked_at"`           // When the cutoff was set
+}
@@ -6,11 +6,58 @@ import (
 
 // User represents the schema for the user entity stored in the database.
 type User struct {
-	ID        string    `json:"id" db:"id"`                       // Primary key (UUID)
-	Auth0ID   string    `json:"auth0_id" db:"auth0_id"`           // Auth0 unique identifier
-	Email     string    `json:"email" db:"email"`                 // User's email address
-	Username  *string   `json:"username,omitempty" db:"username"` // Optional username
-	CreatedAt time.Time `json:"created_at" db:"created_at"`       // Timestamp when the user was created
+	ID          string     `json:"id" db:"id"`                                 // Primary key (UUID)
+	Auth0ID     string     `json:"auth0_id" db:"auth0_id"`                     // Auth0 unique identifier
+	Email       string     `json:"email" db:"email"`                           // User's email address
+	Username    *string    `json:"username,omitempty" db:"username"`           // Optional username
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`                 // Timestamp when the user was created
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`       // Set when the user was soft-deleted
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`                 // Timestamp of the last change to the row
+	LastLoginAt *time.Time `json:"last_login_at,omitempty" db:"last_login_at"` // Timestamp of the most recent login
+	ErasedAt    *time.Time `json:"erased_at,omitempty" db:"erased_at"`         // Set once personal data was erased
+	Roles       []string   `json:"roles,omitempty" db:"roles"`                 // Names of assigned local roles
+
+	Status          string     `json:"status" db:"status"`                                 // One of the UserStatus constants
+	StatusReason    *string    `json:"status_reason,omitempty" db:"status_reason"`         // Why the account is restricted
+	StatusExpiresAt *time.Time `json:"status_expires_at,omitempty" db:"status_expires_at"` // When a suspension lifts
+	StatusChangedBy *string    `json:"status_changed_by,omitempty" db:"status_changed_by"` // Moderator who set the status
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty" db:"status_changed_at"` // When the status was last set
+
+	DisplayName *string    `json:"display_name,omitempty" db:"display_name"`   // Optional public name
+	AvatarURL   *string    `json:"avatar_url,omitempty" db:"avatar_url"`       // Optional https avatar image
+	Bio         *string    `json:"bio,omitempty" db:"bio"`                     // Optional short biography
+	Locale      *string    `json:"locale,omitempty" db:"locale"`               // Optional BCP 47 language tag
+	Timezone    *string    `json:"timezone,omitempty" db:"timezone"`           // Optional IANA time zone
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty" db:"date_of_birth"` // Optional date of birth
+}
+
+// Account statuses stored in users.status.
+const (
+	UserStatusActive              = "active"
+	UserStatusSuspended           = "suspended"
+	UserStatusBanned              = "banned"
+	UserStatusPendingVerification = "pending_verification"
+)
+
+// IsRestricted reports whether the account may not be used at the given time: it is banned,
+// or suspended without an expiry or until after now.
+func (u *User) IsRestricted(now time.Time) bool {
+	switch u.Status {
+	case UserStatusBanned:
+		return true
+	case UserStatusSuspended:
+		return u.StatusExpiresAt == nil || u.StatusExpiresAt.After(now)
+	default:
+		return false
+	}
+}
+
+// UserStatusChange is a moderation decision applied to a user.
+type UserStatusChange struct {
+	Status    string
+	Reason    *string
+	ExpiresAt *time.Time // Suspensions only; nil suspends indefinitely
+	ChangedBy *string
 }
 
 // CreateUserInput represents the data required to create a new user.
@@ -25,3 +72,15 @@ type UpdateUserInput struct {
 	Auth0ID  string `json:"auth0_id" validate:"required"`               // Required to identify the user
 	Username string `json:"username" validate:"omitempty,min=3,max=50"` // Optional username validation
 }
+
+// UserProfileUpdate holds the fields to change on a user. Nil fields are left untouched;
+// an empty string clears an optional profile field. Email cannot be cleared.
+type UserProfileUpdate struct {
+	Email       *string
+	DisplayName *string
+	AvatarURL   *string
+	Bio         *string
+	Locale      *string
+	Timezone    *string
+	DateOfBirth *string // YYYY-MM-DD
+}
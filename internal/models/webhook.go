@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery states.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookSubscription is a partner endpoint receiving user lifecycle events.
+type WebhookSubscription struct {
+	ID         string    `json:"id" db:"id"`                           // Primary key (UUID)
+	URL        string    `json:"url" db:"url"`                         // Endpoint receiving POST requests
+	Secret     string    `json:"-" db:"secret"`                        // HMAC signing key
+	EventTypes []string  `json:"event_types" db:"event_types"`         // Subscribed event types, empty for all
+	Active     bool      `json:"active" db:"active"`                   // Paused subscriptions receive nothing
+	CreatedBy  *string   `json:"created_by,omitempty" db:"created_by"` // Admin who created it
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookDelivery is one event sent (or to be sent) to one subscription.
+type WebhookDelivery struct {
+	ID             int64           `json:"id" db:"id"`
+	SubscriptionID string          `json:"subscription_id" db:"subscription_id"`
+	EventID        int64           `json:"event_id" db:"event_id"` // Outbox event ID
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"` // Request body
+	Status         string          `json:"status" db:"status"`   // pending, delivered, dead
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty" db:"last_status_code"` // HTTP status of the last attempt
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// WebhookSubscriptionUpdate holds the fields to change on a subscription. Nil fields are left untouched.
+type WebhookSubscriptionUpdate struct {
+	URL        *string
+	EventTypes []string // Replaces the subscribed types when non-nil
+	Active     *bool
+}
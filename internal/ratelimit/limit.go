@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket allowing Count calls per Per, in bursts of up to Count.
+// The zero Limit is unlimited; a zero Count with a period blocks every call.
+type Limit struct {
+	Count int
+	Per   time.Duration
+}
+
+// Unlimited reports whether the limit lets every call through.
+func (l Limit) Unlimited() bool {
+	return l.Per <= 0
+}
+
+// Blocked reports whether the limit rejects every call, e.g. "0/m".
+func (l Limit) Blocked() bool {
+	return !l.Unlimited() && l.Count <= 0
+}
+
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "off"
+	}
+	if l.Blocked() {
+		return "blocked"
+	}
+	return fmt.Sprintf("%d/%s", l.Count, l.Per)
+}
+
+// units are the shorthand periods accepted by ParseLimit.
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+}
+
+// ParseLimit parses a limit such as "5/m" (five per minute), "100/s" or "20/10m".
+// "off" and "0" mean unlimited, while a zero count such as "0/m" blocks the RPC entirely.
+func ParseLimit(value string) (Limit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "off" || value == "0" {
+		return Limit{}, nil
+	}
+
+	rawCount, rawPer, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <count>/<period>", value)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(rawCount))
+	if err != nil || count < 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: count must be a non-negative integer", value)
+	}
+
+	rawPer = strings.TrimSpace(rawPer)
+	per, ok := units[rawPer]
+	if !ok {
+		if per, err = time.ParseDuration(rawPer); err != nil || per <= 0 {
+			return Limit{}, fmt.Errorf("invalid rate limit %q: period must be s, m, h, d or a duration", value)
+		}
+	}
+	return Limit{Count: count, Per: per}, nil
+}
+
+// Policy maps RPCs to their limits.
+type Policy struct {
+	Default Limit            // Applies to every RPC without an override
+	Methods map[string]Limit // Overrides keyed by RPC name, e.g. "CreateUser"
+}
+
+// ParsePolicy builds a policy from a default limit and "<RPC>=<limit>" overrides.
+func ParsePolicy(defaultLimit string, overrides []string) (*Policy, error) {
+	limit, err := ParseLimit(defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{Default: limit, Methods: make(map[string]Limit, len(overrides))}
+	for _, override := range overrides {
+		method, rawLimit, found := strings.Cut(override, "=")
+		method = strings.TrimSpace(method)
+		if !found || method == "" {
+			return nil, fmt.Errorf("invalid rate limit override %q: expected <RPC>=<limit>", override)
+		}
+		if policy.Methods[method], err = ParseLimit(rawLimit); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// For returns the limit for a full gRPC method name such as "/user.UserService/CreateUser".
+func (p *Policy) For(fullMethod string) Limit {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if limit, ok := p.Methods[name]; ok {
+		return limit
+	}
+	return p.Default
+}
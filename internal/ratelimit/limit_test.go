@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Limit
+		wantErr bool
+	}{
+		{value: "", want: Limit{}},
+		{value: "off", want: Limit{}},
+		{value: "0", want: Limit{}},
+		{value: "5/m", want: Limit{Count: 5, Per: time.Minute}},
+		{value: " 100 / s ", want: Limit{Count: 100, Per: time.Second}},
+		{value: "2/d", want: Limit{Count: 2, Per: 24 * time.Hour}},
+		{value: "20/10m", want: Limit{Count: 20, Per: 10 * time.Minute}},
+		{value: "0/m", want: Limit{Count: 0, Per: time.Minute}},
+		{value: "5", wantErr: true},
+		{value: "-1/m", wantErr: true},
+		{value: "five/m", wantErr: true},
+		{value: "5/week", wantErr: true},
+		{value: "5/-1m", wantErr: true},
+		{value: "5/0s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLimit(%q) error = %v, want error: %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLimit(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitModes(t *testing.T) {
+	tests := []struct {
+		limit         Limit
+		wantUnlimited bool
+		wantBlocked   bool
+		wantString    string
+	}{
+		{Limit{}, true, false, "off"},
+		{Limit{Count: 5}, true, false, "off"},
+		{Limit{Count: 0, Per: time.Minute}, false, true, "blocked"},
+		{Limit{Count: 5, Per: time.Minute}, false, false, "5/1m0s"},
+	}
+	for _, tt := range tests {
+		if got := tt.limit.Unlimited(); got != tt.wantUnlimited {
+			t.Errorf("%+v.Unlimited() = %v, want %v", tt.limit, got, tt.wantUnlimited)
+		}
+		if got := tt.limit.Blocked(); got != tt.wantBlocked {
+			t.Errorf("%+v.Blocked() = %v, want %v", tt.limit, got, tt.wantBlocked)
+		}
+		if got := tt.limit.String(); got != tt.wantString {
+			t.Errorf("%+v.String() = %q, want %q", tt.limit, got, tt.wantString)
+		}
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultRate string
+		overrides   []string
+		want        map[string]Limit // Keyed by full method name
+		wantErr     bool
+	}{
+		{
+			name:        "default only",
+			defaultRate: "10/s",
+			want:        map[string]Limit{"/user.UserService/GetUser": {Count: 10, Per: time.Second}},
+		},
+		{
+			name:        "overrides",
+			defaultRate: "off",
+			overrides:   []string{"CreateUser=5/m", " DeleteUser = 0/h "},
+			want: map[string]Limit{
+				"/user.UserService/CreateUser": {Count: 5, Per: time.Minute},
+				"/user.UserService/DeleteUser": {Count: 0, Per: time.Hour},
+				"/user.UserService/GetUser":    {},
+			},
+		},
+		{name: "invalid default", defaultRate: "fast", wantErr: true},
+		{name: "override without limit", defaultRate: "off", overrides: []string{"CreateUser"}, wantErr: true},
+		{name: "override without method", defaultRate: "off", overrides: []string{"=5/m"}, wantErr: true},
+		{name: "invalid override limit", defaultRate: "off", overrides: []string{"CreateUser=5"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := ParsePolicy(tt.defaultRate, tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePolicy() error = %v, want error: %v", err, tt.wantErr)
+			}
+			for method, want := range tt.want {
+				if got := policy.For(method); got != want {
+					t.Errorf("For(%q) = %+v, want %+v", method, got, want)
+				}
+			}
+		})
+	}
+}
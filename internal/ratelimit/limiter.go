@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket store. The in-memory implementation limits each instance on its own;
+// a shared backend (e.g. Redis) can implement the same interface to limit across instances.
+type Limiter interface {
+	// Allow takes a token from the bucket identified by key. When the bucket is empty it
+	// returns false and how long until a token becomes available.
+	Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error)
+}
+
+// sweepInterval is how often idle buckets are dropped from a MemoryLimiter.
+const sweepInterval = time.Minute
+
+// MemoryLimiter keeps token buckets in process memory.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweptAt time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+// NewMemoryLimiter creates an empty in-memory limiter.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucket)}
+}
+
+// Allow implements Limiter.
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if limit.Unlimited() {
+		return true, 0, nil
+	}
+	if limit.Blocked() {
+		return false, limit.Per, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.sweptAt) > sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: float64(limit.Count), updatedAt: now, limit: limit}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+	return false, time.Duration((1 - b.tokens) / b.rate()), nil
+}
+
+// sweep drops buckets that have refilled completely, since they are equivalent to new ones.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.refill(now); b.tokens >= float64(b.limit.Count) {
+			delete(l.buckets, key)
+		}
+	}
+	l.sweptAt = now
+}
+
+// rate returns the refill rate in tokens per nanosecond.
+func (b *bucket) rate() float64 {
+	return float64(b.limit.Count) / float64(b.limit.Per)
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens = min(float64(b.limit.Count), b.tokens+float64(elapsed)*b.rate())
+		b.updatedAt = now
+	}
+}
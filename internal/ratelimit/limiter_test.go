@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     Limit
+		calls     int
+		wantAllow int
+	}{
+		{"unlimited", Limit{}, 5, 5},
+		{"blocked", Limit{Count: 0, Per: time.Minute}, 3, 0},
+		{"burst", Limit{Count: 3, Per: time.Hour}, 5, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewMemoryLimiter()
+			allowed := 0
+			for range tt.calls {
+				ok, retryAfter, err := limiter.Allow(context.Background(), "caller", tt.limit)
+				if err != nil {
+					t.Fatalf("Allow() = %v", err)
+				}
+				if ok {
+					allowed++
+				} else if retryAfter <= 0 {
+					t.Errorf("Allow() denied with retry after %v, want a positive delay", retryAfter)
+				}
+			}
+			if allowed != tt.wantAllow {
+				t.Errorf("allowed %d of %d calls, want %d", allowed, tt.calls, tt.wantAllow)
+			}
+		})
+	}
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	limit := Limit{Count: 1, Per: time.Hour}
+	for _, key := range []string{"a", "b"} {
+		if ok, _, _ := limiter.Allow(context.Background(), key, limit); !ok {
+			t.Errorf("Allow(%q) denied the first call", key)
+		}
+	}
+	if ok, _, _ := limiter.Allow(context.Background(), "a", limit); ok {
+		t.Error("Allow(\"a\") allowed a second call within the period")
+	}
+}
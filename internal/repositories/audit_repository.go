@@ -0,0 +1,357 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// auditGenesisHash is the prev_hash of the first audit entry.
+var auditGenesisHash = make([]byte, sha256.Size)
+
+type AuditRepository struct {
+	DB *sql.DB
+}
+
+// NewAuditRepository creates a new instance of AuditRepository.
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{DB: db}
+}
+
+// ✅ Append - Adds an entry to the audit log in its own transaction
+func (r *AuditRepository) Append(event *models.AuditEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := appendAuditEvent(tx, event); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// appendAuditEvent chains and inserts an entry in the caller's transaction. Writers are serialized
+// with a transaction-level advisory lock, so entries are chained in commit order.
+func appendAuditEvent(tx *sql.Tx, event *models.AuditEvent) error {
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('audit_log'))`); err != nil {
+		return err
+	}
+
+	prevHash := auditGenesisHash
+	err := tx.QueryRow(`SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if err := sealAuditEvent(event, prevHash); err != nil {
+		return err
+	}
+
+	var changes interface{}
+	if len(event.Changes) > 0 {
+		changes = []byte(event.Changes)
+	}
+
+	query := `
+		INSERT INTO audit_log (occurred_at, actor_subject, impersonator_subject, action, rpc, target_user_id,
+			target_auth0_id, target_auth0_id_salt, target_auth0_id_digest, request_id, client_ip, client_ip_salt,
+			client_ip_digest, changes, changes_digest, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id
+	`
+	return tx.QueryRow(query, event.OccurredAt, event.ActorSubject, event.ImpersonatorSubject, event.Action, event.RPC, event.TargetUserID,
+		event.TargetAuth0ID, event.TargetAuth0IDSalt, event.TargetAuth0IDDigest, event.RequestID, event.ClientIP, event.ClientIPSalt,
+		event.ClientIPDigest, changes, event.ChangesDigest, event.PrevHash, event.Hash,
+	).Scan(&event.ID)
+}
+
+// sealAuditEvent fills in the digests of an entry and chains it to prevHash.
+func sealAuditEvent(event *models.AuditEvent, prevHash []byte) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	event.OccurredAt = event.OccurredAt.UTC().Truncate(time.Microsecond)
+	digest := sha256.Sum256(event.Changes)
+	event.ChangesDigest = digest[:]
+
+	var err error
+	if event.TargetAuth0IDSalt, event.TargetAuth0IDDigest, err = saltedDigest(event.TargetAuth0ID); err != nil {
+		return err
+	}
+	if event.ClientIPSalt, event.ClientIPDigest, err = saltedDigest(event.ClientIP); err != nil {
+		return err
+	}
+	event.PrevHash = prevHash
+	event.Hash = auditHash(event)
+	return nil
+}
+
+// saltedDigest returns a random salt and the SHA-256 of the salt and value, or nils when value is unset.
+func saltedDigest(value *string) (salt, digest []byte, err error) {
+	if value == nil {
+		return nil, nil, nil
+	}
+	salt = make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, err
+	}
+	return salt, digestWithSalt(salt, *value), nil
+}
+
+func digestWithSalt(salt []byte, value string) []byte {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write([]byte(value))
+	return hash.Sum(nil)
+}
+
+// auditHash computes SHA-256 over the previous hash and every field of the entry except the raw
+// changes, target Auth0 ID and client IP, which are covered by their digests so they can be scrubbed.
+// The impersonator is only hashed when set, so entries written before it existed still verify.
+func auditHash(event *models.AuditEvent) []byte {
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	digested := func(digest []byte) string {
+		if digest == nil {
+			return ""
+		}
+		return "digest:" + hex.EncodeToString(digest)
+	}
+
+	fields := []string{
+		event.OccurredAt.UTC().Format(time.RFC3339Nano),
+		optional(event.ActorSubject),
+		event.Action,
+		optional(event.RPC),
+		optional(event.TargetUserID),
+		digested(event.TargetAuth0IDDigest),
+		optional(event.RequestID),
+		digested(event.ClientIPDigest),
+		hex.EncodeToString(event.ChangesDigest),
+	}
+	if event.ImpersonatorSubject != nil {
+		fields = append(fields, "impersonator", *event.ImpersonatorSubject)
+	}
+
+	hash := sha256.New()
+	hash.Write(event.PrevHash)
+	for _, field := range fields {
+		fmt.Fprintf(hash, "%d:%s", len(field), field)
+	}
+	return hash.Sum(nil)
+}
+
+// AuditFilter selects audit entries.
+type AuditFilter struct {
+	ActorSubject  string     // Optional; also matches the impersonator
+	TargetUserID  string     // Optional (UUID)
+	TargetAuth0ID string     // Optional
+	Action        string     // Optional
+	OccurredAfter *time.Time // Optional, inclusive
+	OccurredUntil *time.Time // Optional, exclusive
+	BeforeID      int64      // Keyset cursor; 0 starts at the newest
+	Limit         int
+}
+
+// auditColumns is the column list scanned by scanAuditEvent.
+const auditColumns = `id, occurred_at, actor_subject, impersonator_subject, action, rpc, target_user_id, target_auth0_id,
+	target_auth0_id_salt, target_auth0_id_digest, request_id, client_ip, client_ip_salt, client_ip_digest, changes,
+	changes_digest, prev_hash, hash, redacted_at`
+
+func scanAuditEvent(row rowScanner) (*models.AuditEvent, error) {
+	var event models.AuditEvent
+	var changes []byte
+	err := row.Scan(&event.ID, &event.OccurredAt, &event.ActorSubject, &event.ImpersonatorSubject, &event.Action, &event.RPC, &event.TargetUserID,
+		&event.TargetAuth0ID, &event.TargetAuth0IDSalt, &event.TargetAuth0IDDigest, &event.RequestID, &event.ClientIP, &event.ClientIPSalt,
+		&event.ClientIPDigest, &changes, &event.ChangesDigest, &event.PrevHash, &event.Hash, &event.RedactedAt)
+	if err != nil {
+		return nil, err
+	}
+	event.Changes = changes
+	return &event, nil
+}
+
+// ✅ ListEvents - Returns matching audit entries, newest first
+func (r *AuditRepository) ListEvents(filter AuditFilter) ([]*models.AuditEvent, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorSubject != "" {
+		add("$%[1]d IN (actor_subject, impersonator_subject)", filter.ActorSubject)
+	}
+	if filter.TargetUserID != "" {
+		add("target_user_id = $%d", filter.TargetUserID)
+	}
+	if filter.TargetAuth0ID != "" {
+		add("target_auth0_id = $%d", filter.TargetAuth0ID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.OccurredAfter != nil {
+		add("occurred_at >= $%d", filter.OccurredAfter.UTC())
+	}
+	if filter.OccurredUntil != nil {
+		add("occurred_at < $%d", filter.OccurredUntil.UTC())
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+
+	query := `SELECT ` + auditColumns + ` FROM audit_log`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// ✅ VerifyChain - Recomputes the hash chain from the first entry. Returns how many entries verified
+// and the ID of the first entry that does not (0 when the whole log is intact).
+func (r *AuditRepository) VerifyChain(ctx context.Context) (verified int64, firstInvalidID int64, err error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+auditColumns+` FROM audit_log ORDER BY id`)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer rows.Close()
+
+	prevHash := auditGenesisHash
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return verified, 0, err
+		}
+
+		if !auditEntryIntact(event, prevHash) {
+			return verified, event.ID, nil
+		}
+		prevHash = event.Hash
+		verified++
+	}
+	return verified, 0, rows.Err()
+}
+
+// auditEntryIntact reports whether an entry follows prevHash and still matches its hash and digests.
+func auditEntryIntact(event *models.AuditEvent, prevHash []byte) bool {
+	return bytes.Equal(event.PrevHash, prevHash) && bytes.Equal(auditHash(event), event.Hash) && auditDigestsMatch(event)
+}
+
+// auditDigestsMatch checks the values covered by digests against them. Redacted changes and
+// scrubbed values (nulled with their salt) are no longer there to check.
+func auditDigestsMatch(event *models.AuditEvent) bool {
+	if event.RedactedAt == nil {
+		if digest := sha256.Sum256(event.Changes); !bytes.Equal(digest[:], event.ChangesDigest) {
+			return false
+		}
+	}
+	return saltedDigestMatches(event.TargetAuth0ID, event.TargetAuth0IDSalt, event.TargetAuth0IDDigest) &&
+		saltedDigestMatches(event.ClientIP, event.ClientIPSalt, event.ClientIPDigest)
+}
+
+// saltedDigestMatches reports whether a digested value is intact: either still present with its
+// salt and matching the digest, or scrubbed together with its salt.
+func saltedDigestMatches(value *string, salt, digest []byte) bool {
+	if digest == nil {
+		return value == nil && salt == nil
+	}
+	if value == nil || salt == nil {
+		return value == nil && salt == nil
+	}
+	return bytes.Equal(digestWithSalt(salt, *value), digest)
+}
+
+// recordUserAudit audits a change from before to after (either may be nil) in the caller's
+// transaction. Nothing is recorded when no audited field changed.
+func recordUserAudit(tx *sql.Tx, audit models.AuditEvent, action string, before, after *models.User) error {
+	changes := userAuditChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	target := after
+	if target == nil {
+		target = before
+	}
+	audit.Action = action
+	audit.TargetUserID = &target.ID
+	audit.TargetAuth0ID = &target.Auth0ID
+	audit.Changes = encoded
+	return appendAuditEvent(tx, &audit)
+}
+
+// userAuditChanges diffs the audited fields of two snapshots of a user.
+func userAuditChanges(before, after *models.User) map[string]models.AuditChange {
+	values := func(user *models.User) map[string]string {
+		if user == nil {
+			return nil
+		}
+		fields := make(map[string]string)
+		for _, field := range userFieldValues(user) {
+			if field.value != "" {
+				fields[field.name] = field.value
+			}
+		}
+		if user.DeletedAt != nil {
+			fields["deleted_at"] = user.DeletedAt.UTC().Format(time.RFC3339Nano)
+		}
+		return fields
+	}
+
+	old, new := values(before), values(after)
+	changes := make(map[string]models.AuditChange)
+	for _, name := range append(userFieldNames, "deleted_at") {
+		oldValue, hadOld := old[name]
+		newValue, hasNew := new[name]
+		if oldValue == newValue && hadOld == hasNew {
+			continue
+		}
+		var change models.AuditChange
+		if hadOld {
+			change.Old = &oldValue
+		}
+		if hasNew {
+			change.New = &newValue
+		}
+		changes[name] = change
+	}
+	return changes
+}
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+func stringPtr(s string) *string { return &s }
+
+// auditChain seals three entries in order, as appendAuditEvent does.
+func auditChain(t *testing.T) []*models.AuditEvent {
+	t.Helper()
+	events := []*models.AuditEvent{
+		{Action: models.AuditUserCreated, TargetAuth0ID: stringPtr("auth0|a"), ClientIP: stringPtr("203.0.113.7"), Changes: json.RawMessage(`{"email":{"new":"a@example.com"}}`)},
+		{Action: models.AuditUserUpdated, ActorSubject: stringPtr("auth0|admin"), RPC: stringPtr("/user.UserService/UpdateUser"), TargetAuth0ID: stringPtr("auth0|a"), ClientIP: stringPtr("198.51.100.1"), Changes: json.RawMessage(`{"bio":{"old":"x","new":"y"}}`)},
+		{Action: models.AuditUserDeleted, TargetAuth0ID: stringPtr("auth0|a")},
+	}
+	prevHash := auditGenesisHash
+	for i, event := range events {
+		event.ID = int64(i + 1)
+		event.OccurredAt = time.Date(2026, 1, 2, 3, 4, 5, 6789, time.UTC).Add(time.Duration(i) * time.Minute)
+		if err := sealAuditEvent(event, prevHash); err != nil {
+			t.Fatalf("sealAuditEvent() = %v", err)
+		}
+		prevHash = event.Hash
+	}
+	return events
+}
+
+// firstInvalid returns the ID of the first entry that fails verification, as VerifyChain does, or 0.
+func firstInvalid(events []*models.AuditEvent) int64 {
+	prevHash := auditGenesisHash
+	for _, event := range events {
+		if !auditEntryIntact(event, prevHash) {
+			return event.ID
+		}
+		prevHash = event.Hash
+	}
+	return 0
+}
+
+func TestAuditChainTamperDetection(t *testing.T) {
+	redactedAt := time.Now()
+
+	tests := []struct {
+		name   string
+		tamper func(events []*models.AuditEvent) []*models.AuditEvent
+		want   int64
+	}{
+		{"untouched", func(e []*models.AuditEvent) []*models.AuditEvent { return e }, 0},
+		{"action changed", func(e []*models.AuditEvent) []*models.AuditEvent { e[1].Action = models.AuditUserDeleted; return e }, 2},
+		{"actor changed", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].ActorSubject = stringPtr("auth0|other")
+			return e
+		}, 2},
+		{"time changed", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].OccurredAt = e[1].OccurredAt.Add(time.Microsecond)
+			return e
+		}, 2},
+		{"impersonator added", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].ImpersonatorSubject = stringPtr("auth0|staff")
+			return e
+		}, 2},
+		{"changes rewritten", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].Changes = json.RawMessage(`{"bio":{"new":"z"}}`)
+			return e
+		}, 2},
+		{"changes dropped without redaction", func(e []*models.AuditEvent) []*models.AuditEvent { e[1].Changes = nil; return e }, 2},
+		{"changes redacted", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].Changes, e[1].RedactedAt = nil, &redactedAt
+			return e
+		}, 0},
+		{"target scrubbed with its salt", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].TargetAuth0ID, e[1].TargetAuth0IDSalt = nil, nil
+			return e
+		}, 0},
+		{"client ip scrubbed with its salt", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].ClientIP, e[1].ClientIPSalt = nil, nil
+			return e
+		}, 0},
+		{"target nulled but salt kept", func(e []*models.AuditEvent) []*models.AuditEvent { e[1].TargetAuth0ID = nil; return e }, 2},
+		{"target replaced", func(e []*models.AuditEvent) []*models.AuditEvent { e[1].TargetAuth0ID = stringPtr("auth0|b"); return e }, 2},
+		{"client ip replaced", func(e []*models.AuditEvent) []*models.AuditEvent { e[1].ClientIP = stringPtr("192.0.2.1"); return e }, 2},
+		{"digest replaced", func(e []*models.AuditEvent) []*models.AuditEvent { e[1].ClientIPDigest = e[0].ClientIPDigest; return e }, 2},
+		{"hash recomputed after edit", func(e []*models.AuditEvent) []*models.AuditEvent {
+			e[1].Action = models.AuditUserDeleted
+			e[1].Hash = auditHash(e[1])
+			return e
+		}, 3},
+		{"entry deleted", func(e []*models.AuditEvent) []*models.AuditEvent { return []*models.AuditEvent{e[0], e[2]} }, 3},
+		{"entries swapped", func(e []*models.AuditEvent) []*models.AuditEvent { return []*models.AuditEvent{e[1], e[0], e[2]} }, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firstInvalid(tt.tamper(auditChain(t))); got != tt.want {
+				t.Errorf("first invalid entry = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaltedDigest(t *testing.T) {
+	salt, digest, err := saltedDigest(nil)
+	if salt != nil || digest != nil || err != nil {
+		t.Errorf("saltedDigest(nil) = %x, %x, %v, want nils", salt, digest, err)
+	}
+
+	value := "203.0.113.7"
+	first, firstDigest, _ := saltedDigest(&value)
+	second, secondDigest, _ := saltedDigest(&value)
+	if len(first) != 16 || string(first) == string(second) {
+		t.Errorf("saltedDigest() salts = %x, %x, want distinct 16-byte salts", first, second)
+	}
+	if string(firstDigest) == string(secondDigest) {
+		t.Error("saltedDigest() returned equal digests for different salts")
+	}
+	if !saltedDigestMatches(&value, first, firstDigest) || saltedDigestMatches(&value, second, firstDigest) {
+		t.Error("saltedDigestMatches() does not check the value against its own salt")
+	}
+	if !saltedDigestMatches(nil, nil, nil) || saltedDigestMatches(&value, nil, nil) {
+		t.Error("saltedDigestMatches() does not require a digest for set values only")
+	}
+}
+
+func TestUserAuditChanges(t *testing.T) {
+	deletedAt := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC)
+	dateOfBirth := time.Date(1990, 4, 12, 0, 0, 0, 0, time.UTC)
+	user := func(modify func(*models.User)) *models.User {
+		u := &models.User{ID: "id", Auth0ID: "auth0|a", Email: "a@example.com", Username: stringPtr("jane"), Status: models.UserStatusActive}
+		if modify != nil {
+			modify(u)
+		}
+		return u
+	}
+	// describe renders a change as "old -> new", with "-" for an unset side.
+	describe := func(change models.AuditChange) string {
+		side := func(value *string) string {
+			if value == nil {
+				return "-"
+			}
+			return *value
+		}
+		return side(change.Old) + " -> " + side(change.New)
+	}
+
+	tests := []struct {
+		name          string
+		before, after *models.User
+		want          map[string]string
+	}{
+		{
+			name:  "created",
+			after: user(nil),
+			want:  map[string]string{"email": "- -> a@example.com", "username": "- -> jane", "status": "- -> active"},
+		},
+		{
+			name:   "hard deleted",
+			before: user(nil),
+			want:   map[string]string{"email": "a@example.com -> -", "username": "jane -> -", "status": "active -> -"},
+		},
+		{
+			name:   "unchanged",
+			before: user(nil),
+			after:  user(func(u *models.User) { u.LastLoginAt = &deletedAt; u.Roles = []string{"admin"} }),
+			want:   map[string]string{},
+		},
+		{
+			name:   "profile edited",
+			before: user(func(u *models.User) { u.Bio = stringPtr("old") }),
+			after: user(func(u *models.User) {
+				u.Bio = stringPtr("new")
+				u.DisplayName = stringPtr("Jane")
+				u.DateOfBirth = &dateOfBirth
+			}),
+			want: map[string]string{"bio": "old -> new", "display_name": "- -> Jane", "date_of_birth": "- -> 1990-04-12"},
+		},
+		{
+			name:   "username cleared",
+			before: user(nil),
+			after:  user(func(u *models.User) { u.Username = nil }),
+			want:   map[string]string{"username": "jane -> -"},
+		},
+		{
+			name:   "soft deleted",
+			before: user(nil),
+			after:  user(func(u *models.User) { u.DeletedAt = &deletedAt }),
+			want:   map[string]string{"deleted_at": "- -> 2026-02-01T09:30:00Z"},
+		},
+		{
+			name:   "suspended",
+			before: user(nil),
+			after: user(func(u *models.User) {
+				u.Status, u.StatusReason, u.StatusExpiresAt = models.UserStatusSuspended, stringPtr("spam"), &deletedAt
+			}),
+			want: map[string]string{"status": "active -> suspended", "status_reason": "- -> spam", "status_expires_at": "- -> 2026-02-01T09:30:00Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := userAuditChanges(tt.before, tt.after)
+			got := make(map[string]string, len(changes))
+			for name, change := range changes {
+				got[name] = describe(change)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("userAuditChanges() = %v, want %v", got, tt.want)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("userAuditChanges()[%q] = %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// ErrDocumentExists is returned when publishing a document version that already exists.
+var ErrDocumentExists = errors.New("document version already exists")
+
+type ConsentRepository struct {
+	DB *sql.DB
+}
+
+// NewConsentRepository creates a new instance of ConsentRepository.
+func NewConsentRepository(db *sql.DB) *ConsentRepository {
+	return &ConsentRepository{DB: db}
+}
+
+// legalDocumentColumns is the column list scanned by scanLegalDocument.
+const legalDocumentColumns = `kind, version, url, effective_at, created_by, created_at`
+
+func scanLegalDocument(row rowScanner) (*models.LegalDocument, error) {
+	var doc models.LegalDocument
+	if err := row.Scan(&doc.Kind, &doc.Version, &doc.URL, &doc.EffectiveAt, &doc.CreatedBy, &doc.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// consentColumns is the column list scanned by scanConsentDecision.
+const consentColumns = `id, user_id, kind, version, granted, source, ip_address, user_agent, decided_at`
+
+func scanConsentDecision(row rowScanner) (*models.ConsentDecision, error) {
+	var decision models.ConsentDecision
+	err := row.Scan(&decision.ID, &decision.UserID, &decision.Kind, &decision.Version, &decision.Granted,
+		&decision.Source, &decision.IPAddress, &decision.UserAgent, &decision.DecidedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &decision, nil
+}
+
+// ✅ PublishDocument - Adds a version of a legal document
+func (r *ConsentRepository) PublishDocument(doc *models.LegalDocument) error {
+	query := `
+		INSERT INTO legal_documents (kind, version, url, effective_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+	err := r.DB.QueryRow(query, doc.Kind, doc.Version, doc.URL, doc.EffectiveAt, doc.CreatedBy).Scan(&doc.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrDocumentExists
+	}
+	return err
+}
+
+// ✅ GetDocument - Retrieves a version of a legal document
+func (r *ConsentRepository) GetDocument(kind, version string) (*models.LegalDocument, error) {
+	query := `SELECT ` + legalDocumentColumns + ` FROM legal_documents WHERE kind = $1 AND version = $2`
+	return scanLegalDocument(r.DB.QueryRow(query, kind, version))
+}
+
+// ✅ CurrentDocuments - Returns the version of each document kind currently in effect
+func (r *ConsentRepository) CurrentDocuments() (map[string]*models.LegalDocument, error) {
+	query := `
+		SELECT DISTINCT ON (kind) ` + legalDocumentColumns + `
+		FROM legal_documents
+		WHERE effective_at <= NOW()
+		ORDER BY kind, effective_at DESC, created_at DESC
+	`
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	docs := make(map[string]*models.LegalDocument)
+	for rows.Next() {
+		doc, err := scanLegalDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs[doc.Kind] = doc
+	}
+	return docs, rows.Err()
+}
+
+// ✅ RecordDecisions - Appends consent decisions for a user in one transaction
+func (r *ConsentRepository) RecordDecisions(decisions []*models.ConsentDecision) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO user_consents (user_id, kind, version, granted, source, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, decided_at
+	`
+	for _, d := range decisions {
+		err := tx.QueryRow(query, d.UserID, d.Kind, d.Version, d.Granted, d.Source, d.IPAddress, d.UserAgent).Scan(&d.ID, &d.DecidedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ✅ LatestDecisions - Returns a user's most recent decision for each kind
+func (r *ConsentRepository) LatestDecisions(userID string) (map[string]*models.ConsentDecision, error) {
+	query := `
+		SELECT DISTINCT ON (kind) ` + consentColumns + `
+		FROM user_consents
+		WHERE user_id = $1
+		ORDER BY kind, decided_at DESC, id DESC
+	`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := make(map[string]*models.ConsentDecision)
+	for rows.Next() {
+		decision, err := scanConsentDecision(rows)
+		if err != nil {
+			return nil, err
+		}
+		decisions[decision.Kind] = decision
+	}
+	return decisions, rows.Err()
+}
+
+// ✅ AcceptedVersions - Returns the latest accepted version of each document kind for an active
+// user, by Auth0 ID. Returns sql.ErrNoRows when there is no such user.
+func (r *ConsentRepository) AcceptedVersions(auth0ID string) (map[string]string, error) {
+	query := `
+		SELECT u.id, c.kind, c.version
+		FROM users u
+		LEFT JOIN LATERAL (
+			SELECT DISTINCT ON (kind) kind, version
+			FROM user_consents
+			WHERE user_id = u.id AND version IS NOT NULL
+			ORDER BY kind, decided_at DESC, id DESC
+		) c ON TRUE
+		WHERE u.auth0_id = $1 AND u.deleted_at IS NULL
+	`
+	rows, err := r.DB.Query(query, auth0ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions map[string]string
+	for rows.Next() {
+		var userID string
+		var kind, version sql.NullString
+		if err := rows.Scan(&userID, &kind, &version); err != nil {
+			return nil, err
+		}
+		if versions == nil {
+			versions = make(map[string]string)
+		}
+		if kind.Valid {
+			versions[kind.String] = version.String
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if versions == nil {
+		return nil, sql.ErrNoRows
+	}
+	return versions, nil
+}
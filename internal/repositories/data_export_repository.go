@@ -0,0 +1,316 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type DataExportRepository struct {
+	DB *sql.DB
+}
+
+// NewDataExportRepository creates a new instance of DataExportRepository.
+func NewDataExportRepository(db *sql.DB) *DataExportRepository {
+	return &DataExportRepository{DB: db}
+}
+
+// dataExportColumns is the column list scanned by scanDataExport. The archive is only read by GetArchive.
+const dataExportColumns = `id, user_id, requested_by, status, error, created_at, started_at, completed_at, expires_at`
+
+func scanDataExport(row rowScanner) (*models.DataExport, error) {
+	var export models.DataExport
+	err := row.Scan(&export.ID, &export.UserID, &export.RequestedBy, &export.Status, &export.Error,
+		&export.CreatedAt, &export.StartedAt, &export.CompletedAt, &export.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// ✅ CreateExport - Queues an export job
+func (r *DataExportRepository) CreateExport(export *models.DataExport) error {
+	query := `
+		INSERT INTO data_exports (id, user_id, requested_by, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + dataExportColumns
+	created, err := scanDataExport(r.DB.QueryRow(query, export.ID, export.UserID, export.RequestedBy, export.ExpiresAt))
+	if err != nil {
+		return err
+	}
+	*export = *created
+	return nil
+}
+
+// ✅ GetExport - Retrieves a job without its archive
+func (r *DataExportRepository) GetExport(id string) (*models.DataExport, error) {
+	return scanDataExport(r.DB.QueryRow(`SELECT `+dataExportColumns+` FROM data_exports WHERE id = $1`, id))
+}
+
+// ✅ GetArchive - Retrieves a job's archive (nil until it has succeeded)
+func (r *DataExportRepository) GetArchive(id string) ([]byte, error) {
+	var archive []byte
+	err := r.DB.QueryRow(`SELECT archive FROM data_exports WHERE id = $1`, id).Scan(&archive)
+	return archive, err
+}
+
+// ✅ ClaimPendingExports - Marks up to limit pending jobs as running. Jobs left running since
+// before staleBefore (e.g. by a crashed worker) are claimed again.
+func (r *DataExportRepository) ClaimPendingExports(limit int, staleBefore time.Time) ([]*models.DataExport, error) {
+	query := `
+		UPDATE data_exports SET status = 'running', started_at = NOW()
+		WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE status = 'pending' OR (status = 'running' AND started_at < $2)
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + dataExportColumns
+	rows, err := r.DB.Query(query, limit, staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exports []*models.DataExport
+	for rows.Next() {
+		export, err := scanDataExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, export)
+	}
+	return exports, rows.Err()
+}
+
+// ✅ CompleteExport - Stores the archive of a running job
+func (r *DataExportRepository) CompleteExport(id string, archive []byte) error {
+	query := `
+		UPDATE data_exports SET status = 'succeeded', archive = $2, error = NULL, completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`
+	_, err := r.DB.Exec(query, id, archive)
+	return err
+}
+
+// ✅ FailExport - Records why a running job failed
+func (r *DataExportRepository) FailExport(id, reason string) error {
+	query := `
+		UPDATE data_exports SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`
+	_, err := r.DB.Exec(query, id, reason)
+	return err
+}
+
+// ✅ DeleteExpired - Drops jobs, and their archives, past their expiry
+func (r *DataExportRepository) DeleteExpired() (int64, error) {
+	result, err := r.DB.Exec(`DELETE FROM data_exports WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ✅ CollectUserData - Gathers every row tied to the user into an archive, from a single
+// read-only snapshot so the sections are consistent with each other.
+func (r *DataExportRepository) CollectUserData(ctx context.Context, userID string) (*models.DataExportArchive, error) {
+	tx, err := r.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	archive := &models.DataExportArchive{
+		UsernameHistory:      []*models.UsernameChange{},
+		UsernameReservations: []*models.UsernameReservation{},
+		LoginEvents:          []*models.LoginEvent{},
+		Sessions:             []*models.Session{},
+		Consents:             []*models.ConsentDecision{},
+		Roles:                []*models.UserRole{},
+		Impersonations:       []*models.Impersonation{},
+		RevokedTokens:        []*models.RevokedToken{},
+		TokenRevocations:     []*models.UserTokenRevocation{},
+		IdempotencyKeys:      []*models.IdempotencyKeyUsage{},
+		Events:               []*models.OutboxEvent{},
+		AuditLog:             []*models.AuditEvent{},
+		PreviousDataExports:  []*models.DataExport{},
+	}
+
+	if err := tx.QueryRowContext(ctx, `SELECT NOW()`).Scan(&archive.GeneratedAt); err != nil {
+		return nil, err
+	}
+	if archive.User, err = scanUser(tx.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, userID)); err != nil {
+		return nil, err
+	}
+	auth0ID := archive.User.Auth0ID
+
+	// Each section is a query plus how to scan one of its rows.
+	sections := []struct {
+		query string
+		args  []interface{}
+		scan  func(rowScanner) error
+	}{
+		{
+			`SELECT old_username, new_username, changed_at FROM username_history WHERE user_id = $1 ORDER BY changed_at, id`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				var change models.UsernameChange
+				archive.UsernameHistory = append(archive.UsernameHistory, &change)
+				return row.Scan(&change.OldUsername, &change.NewUsername, &change.ChangedAt)
+			},
+		},
+		{
+			`SELECT username, expires_at FROM username_reservations WHERE auth0_id = $1 ORDER BY expires_at`,
+			[]interface{}{auth0ID},
+			func(row rowScanner) error {
+				var reservation models.UsernameReservation
+				archive.UsernameReservations = append(archive.UsernameReservations, &reservation)
+				return row.Scan(&reservation.Username, &reservation.ExpiresAt)
+			},
+		},
+		{
+			`SELECT id, user_id, occurred_at, ip_address, user_agent, app_version, auth_method
+			FROM login_events WHERE user_id = $1 ORDER BY occurred_at, id`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				var event models.LoginEvent
+				archive.LoginEvents = append(archive.LoginEvents, &event)
+				return row.Scan(&event.ID, &event.UserID, &event.OccurredAt, &event.IPAddress, &event.UserAgent, &event.AppVersion, &event.AuthMethod)
+			},
+		},
+		{
+			`SELECT ` + sessionColumns + ` FROM sessions WHERE user_id = $1 ORDER BY created_at, id`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				session, err := scanSession(row)
+				if err == nil {
+					archive.Sessions = append(archive.Sessions, session)
+				}
+				return err
+			},
+		},
+		{
+			`SELECT ` + consentColumns + ` FROM user_consents WHERE user_id = $1 ORDER BY decided_at, id`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				decision, err := scanConsentDecision(row)
+				if err == nil {
+					archive.Consents = append(archive.Consents, decision)
+				}
+				return err
+			},
+		},
+		{
+			`SELECT r.name, r.description, r.permissions, r.auth0_role_id, r.created_at, ur.user_id, ur.granted_by, ur.granted_at
+			FROM user_roles ur JOIN roles r ON r.name = ur.role
+			WHERE ur.user_id = $1 ORDER BY ur.granted_at, r.name`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				var role models.UserRole
+				archive.Roles = append(archive.Roles, &role)
+				return row.Scan(&role.Name, &role.Description, pq.Array(&role.Permissions), &role.Auth0RoleID, &role.CreatedAt,
+					&role.UserID, &role.GrantedBy, &role.GrantedAt)
+			},
+		},
+		{
+			// Grants to act as the user, and grants the user (as staff) used to act as others.
+			`SELECT i.id, i.actor_subject, i.user_id, u.auth0_id, i.reason, i.created_at, i.expires_at
+			FROM impersonations i JOIN users u ON u.id = i.user_id
+			WHERE i.user_id = $1 OR i.actor_subject = $2 ORDER BY i.created_at, i.id`,
+			[]interface{}{userID, auth0ID},
+			func(row rowScanner) error {
+				var grant models.Impersonation
+				archive.Impersonations = append(archive.Impersonations, &grant)
+				return row.Scan(&grant.ID, &grant.ActorSubject, &grant.UserID, &grant.Subject, &grant.Reason, &grant.CreatedAt, &grant.ExpiresAt)
+			},
+		},
+		{
+			`SELECT jti, subject, expires_at, reason, revoked_by, revoked_at FROM revoked_tokens WHERE subject = $1 ORDER BY revoked_at`,
+			[]interface{}{auth0ID},
+			func(row rowScanner) error {
+				var token models.RevokedToken
+				archive.RevokedTokens = append(archive.RevokedTokens, &token)
+				return row.Scan(&token.JTI, &token.Subject, &token.ExpiresAt, &token.Reason, &token.RevokedBy, &token.RevokedAt)
+			},
+		},
+		{
+			`SELECT subject, revoked_before, expires_at, reason, revoked_by, revoked_at FROM user_token_revocations WHERE subject = $1`,
+			[]interface{}{auth0ID},
+			func(row rowScanner) error {
+				var revocation models.UserTokenRevocation
+				archive.TokenRevocations = append(archive.TokenRevocations, &revocation)
+				return row.Scan(&revocation.Subject, &revocation.RevokedBefore, &revocation.ExpiresAt, &revocation.Reason, &revocation.RevokedBy, &revocation.RevokedAt)
+			},
+		},
+		{
+			`SELECT key, method, created_at, expires_at FROM idempotency_keys WHERE scope = $1 ORDER BY created_at`,
+			[]interface{}{auth0ID},
+			func(row rowScanner) error {
+				var usage models.IdempotencyKeyUsage
+				archive.IdempotencyKeys = append(archive.IdempotencyKeys, &usage)
+				return row.Scan(&usage.Key, &usage.Method, &usage.CreatedAt, &usage.ExpiresAt)
+			},
+		},
+		{
+			`SELECT id, event_type, user_id, payload, created_at FROM outbox_events WHERE user_id = $1 ORDER BY id`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				var event models.OutboxEvent
+				archive.Events = append(archive.Events, &event)
+				return row.Scan(&event.ID, &event.EventType, &event.UserID, &event.Payload, &event.CreatedAt)
+			},
+		},
+		{
+			`SELECT ` + auditColumns + ` FROM audit_log
+			WHERE target_user_id = $1 OR $2 IN (target_auth0_id, actor_subject, impersonator_subject) ORDER BY id`,
+			[]interface{}{userID, auth0ID},
+			func(row rowScanner) error {
+				event, err := scanAuditEvent(row)
+				if err == nil {
+					archive.AuditLog = append(archive.AuditLog, event)
+				}
+				return err
+			},
+		},
+		{
+			`SELECT ` + dataExportColumns + ` FROM data_exports WHERE user_id = $1 ORDER BY created_at`,
+			[]interface{}{userID},
+			func(row rowScanner) error {
+				export, err := scanDataExport(row)
+				if err == nil {
+					archive.PreviousDataExports = append(archive.PreviousDataExports, export)
+				}
+				return err
+			},
+		},
+	}
+
+	for _, section := range sections {
+		if err := querySection(ctx, tx, section.query, section.args, section.scan); err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+// querySection runs a query in the transaction and calls scan for each row.
+func querySection(ctx context.Context, tx *sql.Tx, query string, args []interface{}, scan func(rowScanner) error) error {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
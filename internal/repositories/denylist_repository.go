@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type DenylistRepository struct {
+	DB *sql.DB
+}
+
+// NewDenylistRepository creates a new instance of DenylistRepository.
+func NewDenylistRepository(db *sql.DB) *DenylistRepository {
+	return &DenylistRepository{DB: db}
+}
+
+// ✅ RevokeToken - Adds a token ID to the denylist (keeping the later expiry if it is already there)
+func (r *DenylistRepository) RevokeToken(token *models.RevokedToken) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, subject, expires_at, reason, revoked_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (jti) DO UPDATE SET expires_at = GREATEST(revoked_tokens.expires_at, EXCLUDED.expires_at)
+		RETURNING revoked_at
+	`
+	return r.DB.QueryRow(query, token.JTI, token.Subject, token.ExpiresAt, token.Reason, token.RevokedBy).Scan(&token.RevokedAt)
+}
+
+// ✅ RevokeUserTokens - Rejects every token of the subject issued at or before the cutoff.
+// An existing cutoff is only ever moved later.
+func (r *DenylistRepository) RevokeUserTokens(revocation *models.UserTokenRevocation) error {
+	query := `
+		INSERT INTO user_token_revocations (subject, revoked_before, expires_at, reason, revoked_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (subject) DO UPDATE SET
+			revoked_before = GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before),
+			expires_at = GREATEST(user_token_revocations.expires_at, EXCLUDED.expires_at),
+			reason = EXCLUDED.reason,
+			revoked_by = EXCLUDED.revoked_by,
+			revoked_at = NOW()
+		RETURNING revoked_before, expires_at, revoked_at
+	`
+	row := r.DB.QueryRow(query, revocation.Subject, revocation.RevokedBefore, revocation.ExpiresAt, revocation.Reason, revocation.RevokedBy)
+	return row.Scan(&revocation.RevokedBefore, &revocation.ExpiresAt, &revocation.RevokedAt)
+}
+
+// ✅ ListActiveEntries - Returns unexpired token IDs and per-subject cutoffs for the in-process cache
+func (r *DenylistRepository) ListActiveEntries() (map[string]struct{}, map[string]models.UserTokenRevocation, error) {
+	rows, err := r.DB.Query(`SELECT jti FROM revoked_tokens WHERE expires_at > NOW()`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	tokens := make(map[string]struct{})
+	for rows.Next() {
+		var jti string
+		if err := rows.Scan(&jti); err != nil {
+			return nil, nil, err
+		}
+		tokens[jti] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err = r.DB.Query(`SELECT subject, revoked_before, expires_at FROM user_token_revocations WHERE expires_at > NOW()`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	cutoffs := make(map[string]models.UserTokenRevocation)
+	for rows.Next() {
+		var revocation models.UserTokenRevocation
+		if err := rows.Scan(&revocation.Subject, &revocation.RevokedBefore, &revocation.ExpiresAt); err != nil {
+			return nil, nil, err
+		}
+		cutoffs[revocation.Subject] = revocation
+	}
+	return tokens, cutoffs, rows.Err()
+}
+
+// ✅ DeleteExpired - Garbage-collects denylist entries that can no longer match a valid token
+func (r *DenylistRepository) DeleteExpired() (int64, error) {
+	var total int64
+	for _, query := range []string{
+		`DELETE FROM revoked_tokens WHERE expires_at <= NOW()`,
+		`DELETE FROM user_token_revocations WHERE expires_at <= NOW()`,
+	} {
+		result, err := r.DB.Exec(query)
+		if err != nil {
+			return total, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+	}
+	return total, nil
+}
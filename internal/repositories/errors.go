@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrEmailTaken is returned when another account already uses the email (case-insensitive).
+	ErrEmailTaken = errors.New("email already in use")
+	// ErrUsernameTaken is returned when another account already uses the username (case-insensitive).
+	ErrUsernameTaken = errors.New("username already in use")
+	// ErrAuth0IDTaken is returned when an account already exists for the Auth0 ID.
+	ErrAuth0IDTaken = errors.New("auth0_id already in use")
+	// ErrUserDeleted is returned when the account for the Auth0 ID was deleted and cannot be recreated.
+	ErrUserDeleted = errors.New("account was deleted")
+)
+
+// UsernameCooldownError is returned when an account changes its username again before the cooldown ends.
+type UsernameCooldownError struct {
+	NextChange time.Time // When the username can be changed again
+}
+
+func (e *UsernameCooldownError) Error() string {
+	return fmt.Sprintf("username can be changed again after %s", e.NextChange.UTC().Format(time.RFC3339))
+}
+
+// uniqueViolationErrors maps unique constraint names on the users table to domain errors.
+var uniqueViolationErrors = map[string]error{
+	"users_email_key":          ErrEmailTaken,
+	"users_email_lower_key":    ErrEmailTaken,
+	"users_username_lower_key": ErrUsernameTaken,
+	"users_auth0_id_key":       ErrAuth0IDTaken,
+}
+
+// translateError converts known PostgreSQL errors into repository errors.
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		if mapped, ok := uniqueViolationErrors[pqErr.Constraint]; ok {
+			return mapped
+		}
+	}
+	return err
+}
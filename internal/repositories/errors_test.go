@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection reset")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"email", &pq.Error{Code: "23505", Constraint: "users_email_key"}, ErrEmailTaken},
+		{"email in another case", &pq.Error{Code: "23505", Constraint: "users_email_lower_key"}, ErrEmailTaken},
+		{"username", &pq.Error{Code: "23505", Constraint: "users_username_lower_key"}, ErrUsernameTaken},
+		{"auth0 id", &pq.Error{Code: "23505", Constraint: "users_auth0_id_key"}, ErrAuth0IDTaken},
+		{"other error", other, other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateError(tt.err); !errors.Is(got, tt.want) {
+				t.Errorf("translateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateErrorKeepsUnknownViolations(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *pq.Error
+	}{
+		{"other constraint", &pq.Error{Code: "23505", Constraint: "users_pkey"}},
+		{"not a unique violation", &pq.Error{Code: "23503", Constraint: "users_email_key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateError(tt.err); got != error(tt.err) {
+				t.Errorf("translateError() = %v, want the original error", got)
+			}
+		})
+	}
+}
+
+func TestUsernameCooldownError(t *testing.T) {
+	err := &UsernameCooldownError{NextChange: time.Date(2026, 5, 4, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))}
+	if got, want := err.Error(), "username can be changed again after 2026-05-04T10:30:00Z"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
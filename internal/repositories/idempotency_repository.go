@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type IdempotencyRepository struct {
+	DB *sql.DB
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository.
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{DB: db}
+}
+
+// ✅ ClaimKey - Records the key as in progress unless an unexpired record exists. In-progress records
+// created before staleBefore are assumed abandoned (e.g. the instance crashed) and taken over.
+// Returns nil when the caller now owns the key, or the existing record otherwise.
+func (r *IdempotencyRepository) ClaimKey(record *models.IdempotencyKey, staleBefore time.Time) (*models.IdempotencyKey, error) {
+	query := `
+		INSERT INTO idempotency_keys (scope, key, method, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, key) DO UPDATE SET
+			method = EXCLUDED.method,
+			request_hash = EXCLUDED.request_hash,
+			response = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+			OR (idempotency_keys.response IS NULL AND idempotency_keys.created_at <= $6)
+		RETURNING created_at
+	`
+	err := r.DB.QueryRow(query, record.Scope, record.Key, record.Method, record.RequestHash, record.ExpiresAt, staleBefore).Scan(&record.CreatedAt)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	existing := &models.IdempotencyKey{}
+	query = `
+		SELECT scope, key, method, request_hash, response, created_at, expires_at
+		FROM idempotency_keys WHERE scope = $1 AND key = $2
+	`
+	err = r.DB.QueryRow(query, record.Scope, record.Key).Scan(
+		&existing.Scope, &existing.Key, &existing.Method, &existing.RequestHash,
+		&existing.Response, &existing.CreatedAt, &existing.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The record expired and was collected between the two statements; try again.
+		return r.ClaimKey(record, staleBefore)
+	}
+	return existing, err
+}
+
+// ✅ CompleteKey - Stores the response for a claimed key
+func (r *IdempotencyRepository) CompleteKey(scope, key string, response []byte) error {
+	_, err := r.DB.Exec(`UPDATE idempotency_keys SET response = $3 WHERE scope = $1 AND key = $2`, scope, key, response)
+	return err
+}
+
+// ✅ ReleaseKey - Forgets an in-progress key so the request can be retried
+func (r *IdempotencyRepository) ReleaseKey(scope, key string) error {
+	_, err := r.DB.Exec(`DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND response IS NULL`, scope, key)
+	return err
+}
+
+// ✅ DeleteExpired - Garbage-collects keys older than their TTL
+func (r *IdempotencyRepository) DeleteExpired() (int64, error) {
+	result, err := r.DB.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
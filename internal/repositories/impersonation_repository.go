@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type ImpersonationRepository struct {
+	DB *sql.DB
+}
+
+// NewImpersonationRepository creates a new instance of ImpersonationRepository.
+func NewImpersonationRepository(db *sql.DB) *ImpersonationRepository {
+	return &ImpersonationRepository{DB: db}
+}
+
+// ✅ CreateImpersonation - Stores an impersonation grant
+func (r *ImpersonationRepository) CreateImpersonation(grant *models.Impersonation) error {
+	query := `
+		INSERT INTO impersonations (id, token_hash, actor_subject, user_id, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`
+	return r.DB.QueryRow(query, grant.ID, grant.TokenHash, grant.ActorSubject, grant.UserID, grant.Reason, grant.ExpiresAt).
+		Scan(&grant.CreatedAt)
+}
+
+// ✅ GetActiveImpersonation - Retrieves an unexpired grant by token hash, with the current Auth0 ID
+// of its live user
+func (r *ImpersonationRepository) GetActiveImpersonation(tokenHash []byte) (*models.Impersonation, error) {
+	query := `
+		SELECT i.id, i.actor_subject, i.user_id, u.auth0_id, i.reason, i.created_at, i.expires_at
+		FROM impersonations i
+		JOIN users u ON u.id = i.user_id
+		WHERE i.token_hash = $1 AND i.expires_at > NOW() AND u.deleted_at IS NULL
+	`
+	var grant models.Impersonation
+	err := r.DB.QueryRow(query, tokenHash).Scan(&grant.ID, &grant.ActorSubject, &grant.UserID, &grant.Subject,
+		&grant.Reason, &grant.CreatedAt, &grant.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	grant.TokenHash = tokenHash
+	return &grant, nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type LoginRepository struct {
+	DB *sql.DB
+}
+
+// NewLoginRepository creates a new instance of LoginRepository.
+func NewLoginRepository(db *sql.DB) *LoginRepository {
+	return &LoginRepository{DB: db}
+}
+
+// LoginCursor is a keyset position in (occurred_at, id) descending order.
+type LoginCursor struct {
+	OccurredAt time.Time
+	ID         int64
+}
+
+// ✅ RecordLogin - Appends a login event for the user and advances users.last_login_at
+func (r *LoginRepository) RecordLogin(auth0ID string, event *models.LoginEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users SET last_login_at = GREATEST(last_login_at, $2)
+		WHERE auth0_id = $1 AND deleted_at IS NULL
+		RETURNING id
+	`
+	if err := tx.QueryRow(query, auth0ID, event.OccurredAt).Scan(&event.UserID); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO login_events (user_id, occurred_at, ip_address, user_agent, app_version, auth_method)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	err = tx.QueryRow(query, event.UserID, event.OccurredAt, event.IPAddress, event.UserAgent, event.AppVersion, event.AuthMethod).Scan(&event.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ✅ ListLoginEvents - Lists a user's logins, newest first, after the cursor (nil for the first page)
+func (r *LoginRepository) ListLoginEvents(userID string, after *LoginCursor, limit int) ([]*models.LoginEvent, error) {
+	query := `
+		SELECT id, user_id, occurred_at, ip_address, user_agent, app_version, auth_method
+		FROM login_events
+		WHERE user_id = $1 AND ($2::timestamp IS NULL OR (occurred_at, id) < ($2::timestamp, $3::bigint))
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT $4
+	`
+	var afterTime, afterID interface{}
+	if after != nil {
+		afterTime, afterID = after.OccurredAt, after.ID
+	}
+
+	rows, err := r.DB.Query(query, userID, afterTime, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.LoginEvent
+	for rows.Next() {
+		var event models.LoginEvent
+		err := rows.Scan(&event.ID, &event.UserID, &event.OccurredAt, &event.IPAddress, &event.UserAgent, &event.AppVersion, &event.AuthMethod)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
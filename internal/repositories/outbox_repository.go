@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type OutboxRepository struct {
+	DB *sql.DB
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository.
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{DB: db}
+}
+
+// ✅ PublishPending - Locks up to limit unpublished events in feed order, calls fn for each and marks
+// the delivered ones as published. As in ListEventsAfter, events are ordered by (xact_id, id) and
+// those of transactions that might still be running are held back, since ids are assigned before
+// commit. Stops at the first error; the failed event and everything after it are retried on the next
+// call. Concurrent relays skip each other's locked rows, so order only holds within one relay's batches.
+func (r *OutboxRepository) PublishPending(ctx context.Context, limit int, fn func(*models.OutboxEvent) error) (int, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, event_type, user_id, payload, created_at
+		FROM outbox_events
+		WHERE published_at IS NULL AND xact_id < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY xact_id, id LIMIT $1 FOR UPDATE SKIP LOCKED
+	`
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	events, err := scanOutboxEvents(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	var published []int64
+	var publishErr error
+	for _, event := range events {
+		if publishErr = fn(event); publishErr != nil {
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if len(published) > 0 {
+		_, err := tx.ExecContext(ctx, `UPDATE outbox_events SET published_at = NOW() WHERE id = ANY($1)`, pq.Array(published))
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+	return len(published), publishErr
+}
+
+// ✅ DeletePublishedBefore - Drops published events older than the retention cutoff
+func (r *OutboxRepository) DeletePublishedBefore(cutoff time.Time) (int64, error) {
+	result, err := r.DB.Exec(`DELETE FROM outbox_events WHERE published_at IS NOT NULL AND created_at < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// OutboxPosition is a point in the change feed: every event ordered at or before it has been read.
+type OutboxPosition struct {
+	XactID int64
+	ID     int64
+}
+
+// ✅ CurrentPosition - Returns the head of the change feed, so only events committed from now on follow it
+func (r *OutboxRepository) CurrentPosition(ctx context.Context) (OutboxPosition, error) {
+	var position OutboxPosition
+	err := r.DB.QueryRowContext(ctx, `SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint`).Scan(&position.XactID)
+	return position, err
+}
+
+// ✅ ListEventsAfter - Returns up to limit events after the position in feed order. Events of
+// transactions that might still be running, or that started after an unfinished one, are held back
+// until everything before them has committed.
+func (r *OutboxRepository) ListEventsAfter(ctx context.Context, after OutboxPosition, limit int) ([]*models.OutboxEvent, error) {
+	query := `
+		SELECT id, event_type, user_id, payload, created_at, xact_id::text::bigint
+		FROM outbox_events
+		WHERE (xact_id, id) > ($1::text::xid8, $2::bigint)
+			AND xact_id < pg_snapshot_xmin(pg_current_snapshot())
+		ORDER BY xact_id, id
+		LIMIT $3
+	`
+	rows, err := r.DB.QueryContext(ctx, query, after.XactID, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.EventType, &event.UserID, &event.Payload, &event.CreatedAt, &event.XactID); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// scanOutboxEvents reads and closes rows selected as id, event_type, user_id, payload, created_at.
+func scanOutboxEvents(rows *sql.Rows) ([]*models.OutboxEvent, error) {
+	defer rows.Close()
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		var event models.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.EventType, &event.UserID, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// insertUserEvent writes a user lifecycle event in the caller's transaction.
+func insertUserEvent(tx *sql.Tx, eventType string, user *models.User, changedFields []string) error {
+	payload, err := json.Marshal(models.UserEventPayload{User: user, ChangedFields: changedFields})
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO outbox_events (event_type, user_id, payload) VALUES ($1, $2, $3)`, eventType, user.ID, payload)
+	return err
+}
+
+// userFieldNames are the user-facing fields compared by changedUserFields and audited, in order.
+var userFieldNames = []string{"email", "username", "display_name", "avatar_url", "bio", "locale", "timezone", "date_of_birth",
+	"status", "status_reason", "status_expires_at"}
+
+type userFieldValue struct {
+	name  string
+	value string // "" when unset
+}
+
+// userFieldValues returns the user-facing fields of a user in userFieldNames order.
+func userFieldValues(user *models.User) []userFieldValue {
+	optional := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	dateOfBirth := ""
+	if user.DateOfBirth != nil {
+		dateOfBirth = user.DateOfBirth.Format(time.DateOnly)
+	}
+	statusExpiresAt := ""
+	if user.StatusExpiresAt != nil {
+		statusExpiresAt = user.StatusExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return []userFieldValue{
+		{"email", user.Email},
+		{"username", optional(user.Username)},
+		{"display_name", optional(user.DisplayName)},
+		{"avatar_url", optional(user.AvatarURL)},
+		{"bio", optional(user.Bio)},
+		{"locale", optional(user.Locale)},
+		{"timezone", optional(user.Timezone)},
+		{"date_of_birth", dateOfBirth},
+		{"status", user.Status},
+		{"status_reason", optional(user.StatusReason)},
+		{"status_expires_at", statusExpiresAt},
+	}
+}
+
+// changedUserFields lists the user-facing fields that differ between two snapshots of a user.
+func changedUserFields(before, after *models.User) []string {
+	var changed []string
+	old, new := userFieldValues(before), userFieldValues(after)
+	for i := range old {
+		if old[i].value != new[i].value {
+			changed = append(changed, old[i].name)
+		}
+	}
+	return changed
+}
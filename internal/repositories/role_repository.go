@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type RoleRepository struct {
+	DB *sql.DB
+}
+
+// NewRoleRepository creates a new instance of RoleRepository.
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{DB: db}
+}
+
+// ✅ GetRole - Retrieves a role by name
+func (r *RoleRepository) GetRole(name string) (*models.Role, error) {
+	var role models.Role
+	query := `SELECT name, description, permissions, auth0_role_id, created_at FROM roles WHERE name = $1`
+	err := r.DB.QueryRow(query, name).Scan(&role.Name, &role.Description, pq.Array(&role.Permissions), &role.Auth0RoleID, &role.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// ✅ AssignRole - Assigns a role to a user, reporting false if they already had it
+func (r *RoleRepository) AssignRole(userID, role string, grantedBy *string) (bool, error) {
+	query := `
+		INSERT INTO user_roles (user_id, role, granted_by) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role) DO NOTHING
+	`
+	result, err := r.DB.Exec(query, userID, role, grantedBy)
+	if err != nil {
+		return false, err
+	}
+	inserted, err := result.RowsAffected()
+	return inserted > 0, err
+}
+
+// ✅ RevokeRole - Removes a role from a user, reporting false if they did not have it
+func (r *RoleRepository) RevokeRole(userID, role string) (bool, error) {
+	result, err := r.DB.Exec(`DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, userID, role)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	return deleted > 0, err
+}
+
+// ✅ ListUserRoles - Lists the roles assigned to a user, by name
+func (r *RoleRepository) ListUserRoles(userID string) ([]*models.UserRole, error) {
+	query := `
+		SELECT r.name, r.description, r.permissions, r.auth0_role_id, r.created_at, ur.user_id, ur.granted_by, ur.granted_at
+		FROM user_roles ur
+		JOIN roles r ON r.name = ur.role
+		WHERE ur.user_id = $1
+		ORDER BY r.name
+	`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []*models.UserRole
+	for rows.Next() {
+		var role models.UserRole
+		err := rows.Scan(&role.Name, &role.Description, pq.Array(&role.Permissions), &role.Auth0RoleID, &role.CreatedAt,
+			&role.UserID, &role.GrantedBy, &role.GrantedAt)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+	return roles, rows.Err()
+}
+
+// ✅ ListRolePermissions - Returns the permissions granted by local roles to each active user, by Auth0 ID
+func (r *RoleRepository) ListRolePermissions() (map[string][]string, error) {
+	query := `
+		SELECT u.auth0_id, array_agg(DISTINCT permission ORDER BY permission)
+		FROM users u
+		JOIN user_roles ur ON ur.user_id = u.id
+		JOIN roles r ON r.name = ur.role
+		CROSS JOIN LATERAL unnest(r.permissions) AS permission
+		WHERE u.deleted_at IS NULL
+		GROUP BY u.auth0_id
+	`
+	rows, err := r.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make(map[string][]string)
+	for rows.Next() {
+		var auth0ID string
+		var granted []string
+		if err := rows.Scan(&auth0ID, pq.Array(&granted)); err != nil {
+			return nil, err
+		}
+		permissions[auth0ID] = granted
+	}
+	return permissions, rows.Err()
+}
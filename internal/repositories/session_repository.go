@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type SessionRepository struct {
+	DB *sql.DB
+}
+
+// NewSessionRepository creates a new instance of SessionRepository.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{DB: db}
+}
+
+// sessionColumns is the column list scanned by scanSession.
+const sessionColumns = `id, user_id, device_id, token_family, device_name, platform, app_version,
+	ip_address, user_agent, created_at, last_seen_at, revoked_at`
+
+func scanSession(row rowScanner) (*models.Session, error) {
+	var session models.Session
+	err := row.Scan(&session.ID, &session.UserID, &session.DeviceID, &session.TokenFamily, &session.DeviceName,
+		&session.Platform, &session.AppVersion, &session.IPAddress, &session.UserAgent,
+		&session.CreatedAt, &session.LastSeenAt, &session.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ✅ UpsertSession - Registers the device for the user, or refreshes the active session already open on it
+func (r *SessionRepository) UpsertSession(session *models.Session) (*models.Session, error) {
+	query := `
+		INSERT INTO sessions (id, user_id, device_id, token_family, device_name, platform, app_version, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, device_id) WHERE revoked_at IS NULL DO UPDATE SET
+			token_family = COALESCE(EXCLUDED.token_family, sessions.token_family),
+			device_name = COALESCE(EXCLUDED.device_name, sessions.device_name),
+			platform = COALESCE(EXCLUDED.platform, sessions.platform),
+			app_version = COALESCE(EXCLUDED.app_version, sessions.app_version),
+			ip_address = COALESCE(EXCLUDED.ip_address, sessions.ip_address),
+			user_agent = COALESCE(EXCLUDED.user_agent, sessions.user_agent),
+			last_seen_at = NOW()
+		RETURNING ` + sessionColumns
+	row := r.DB.QueryRow(query, session.ID, session.UserID, session.DeviceID, session.TokenFamily, session.DeviceName,
+		session.Platform, session.AppVersion, session.IPAddress, session.UserAgent)
+	return scanSession(row)
+}
+
+// ✅ ListSessions - Lists the user's sessions, most recently seen first
+func (r *SessionRepository) ListSessions(userID string, includeRevoked bool) ([]*models.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM sessions WHERE user_id = $1`
+	if !includeRevoked {
+		query += ` AND revoked_at IS NULL`
+	}
+	query += ` ORDER BY last_seen_at DESC`
+
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// ✅ RevokeSession - Signs out one of the user's active sessions, returning its token family (if any)
+func (r *SessionRepository) RevokeSession(userID, sessionID string) (string, error) {
+	query := `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+		RETURNING COALESCE(token_family, '')
+	`
+	var family string
+	err := r.DB.QueryRow(query, sessionID, userID).Scan(&family)
+	return family, err
+}
+
+// ✅ RevokeAllSessions - Signs out every active session of the user except those in the kept token family,
+// returning the revoked token families
+func (r *SessionRepository) RevokeAllSessions(userID, keepFamily string) ([]string, error) {
+	query := `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL AND ($2 = '' OR token_family IS DISTINCT FROM $2)
+		RETURNING COALESCE(token_family, '')
+	`
+	return r.queryFamilies(query, userID, keepFamily)
+}
+
+// RevokedFamily is a revoked token family together with the Auth0 ID of the user it belongs to.
+type RevokedFamily struct {
+	Auth0ID string
+	Family  string
+}
+
+// ✅ ListRevokedFamilies - Returns the token families of sessions revoked after since, with their owners
+func (r *SessionRepository) ListRevokedFamilies(since time.Time) ([]RevokedFamily, error) {
+	query := `
+		SELECT u.auth0_id, s.token_family
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.revoked_at > $1 AND s.token_family IS NOT NULL
+	`
+	rows, err := r.DB.Query(query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var families []RevokedFamily
+	for rows.Next() {
+		var family RevokedFamily
+		if err := rows.Scan(&family.Auth0ID, &family.Family); err != nil {
+			return nil, err
+		}
+		families = append(families, family)
+	}
+	return families, rows.Err()
+}
+
+func (r *SessionRepository) queryFamilies(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var families []string
+	for rows.Next() {
+		var family string
+		if err := rows.Scan(&family); err != nil {
+			return nil, err
+		}
+		if family != "" {
+			families = append(families, family)
+		}
+	}
+	return families, rows.Err()
+}
@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// ErrAlreadyErased is returned when erasing a user whose data was already erased.
+var ErrAlreadyErased = errors.New("user already erased")
+
+// erasureParams are the values an erasure step can bind.
+type erasureParams struct {
+	userID    string // Kept UUID
+	auth0ID   string // Auth0 ID before erasure
+	tombstone string // The erased user as JSON, replacing snapshots in event payloads
+}
+
+// erasureSteps scrub or delete a user's rows outside the users table.
+var erasureSteps = []struct {
+	table string
+	query string
+	args  func(p erasureParams) []interface{}
+}{
+	{"username_history", `DELETE FROM username_history WHERE user_id = $1`, byErasedUserID},
+	{"username_reservations", `DELETE FROM username_reservations WHERE auth0_id = $1`, byErasedAuth0ID},
+	{"login_events", `
+		UPDATE login_events SET ip_address = NULL, user_agent = NULL
+		WHERE user_id = $1 AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)`, byErasedUserID},
+	{"sessions", `
+		UPDATE sessions SET device_id = id::text, device_name = NULL, ip_address = NULL, user_agent = NULL,
+			revoked_at = COALESCE(revoked_at, NOW())
+		WHERE user_id = $1`, byErasedUserID},
+	{"user_consents", `
+		UPDATE user_consents SET ip_address = NULL, user_agent = NULL
+		WHERE user_id = $1 AND (ip_address IS NOT NULL OR user_agent IS NOT NULL)`, byErasedUserID},
+	{"revoked_tokens", `UPDATE revoked_tokens SET subject = NULL, reason = NULL WHERE subject = $1`, byErasedAuth0ID},
+	{"idempotency_keys", `DELETE FROM idempotency_keys WHERE scope = $1`, byErasedAuth0ID},
+	{"data_exports", `DELETE FROM data_exports WHERE user_id = $1`, byErasedUserID},
+	{"impersonations", `DELETE FROM impersonations WHERE user_id = $1`, byErasedUserID},
+	{"outbox_events", `
+		UPDATE outbox_events SET payload = jsonb_set(payload, '{user}', $2::jsonb)
+		WHERE user_id = $1`, withTombstone},
+	{"webhook_deliveries", `
+		UPDATE webhook_deliveries SET payload = jsonb_set(payload, '{payload,user}', $2::jsonb)
+		WHERE payload->>'user_id' = $1`, withTombstone},
+	{"webhook_dead_letters", `
+		UPDATE webhook_dead_letters SET payload = jsonb_set(payload, '{payload,user}', $2::jsonb)
+		WHERE payload->>'user_id' = $1`, withTombstone},
+	{"audit_log", `
+		UPDATE audit_log SET changes = NULL, redacted_at = NOW()
+		WHERE (target_user_id = $1 OR target_auth0_id = $2) AND redacted_at IS NULL AND changes IS NOT NULL`,
+		func(p erasureParams) []interface{} { return []interface{}{p.userID, p.auth0ID} }},
+	{"audit_log.target_auth0_id", `
+		UPDATE audit_log SET target_auth0_id = NULL, target_auth0_id_salt = NULL
+		WHERE target_auth0_id = $1 AND target_auth0_id_digest IS NOT NULL`, byErasedAuth0ID},
+	{"audit_log.client_ip", `
+		UPDATE audit_log SET client_ip = NULL, client_ip_salt = NULL
+		WHERE actor_subject = $1 AND client_ip IS NOT NULL AND client_ip_digest IS NOT NULL`, byErasedAuth0ID},
+}
+
+// erasureRetainedCopies are copies of personal data outside the database, which EraseUser cannot scrub.
+var erasureRetainedCopies = []string{
+	"service log: log lines written before the erasure keep the user's Auth0 ID until the log is rotated",
+	"database backups: keep the user's data until they expire",
+	"Auth0: the identity provider keeps its own copy of the account",
+}
+
+func byErasedUserID(p erasureParams) []interface{}  { return []interface{}{p.userID} }
+func byErasedAuth0ID(p erasureParams) []interface{} { return []interface{}{p.auth0ID} }
+func withTombstone(p erasureParams) []interface{}   { return []interface{}{p.userID, p.tombstone} }
+
+// ✅ EraseUser - Replaces a user's personal data with tombstones in one transaction: the users row
+// keeps its UUID, related rows are scrubbed or deleted, and a UserErased event, the certificate and
+// an audit entry are written. Soft-deleted users can be erased too.
+func (r *UserRepository) EraseUser(auth0ID string, certificate *models.ErasureCertificate, audit models.AuditEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := scanUser(tx.QueryRow(`SELECT `+userColumns+` FROM users WHERE auth0_id = $1 FOR UPDATE`, auth0ID))
+	if err != nil {
+		return err
+	}
+	if before.ErasedAt != nil {
+		return ErrAlreadyErased
+	}
+
+	// The tombstones are derived from the UUID only, so nothing about the original values survives.
+	query := `
+		UPDATE users SET auth0_id = 'erased|' || id, email = 'erased+' || id || '@erased.invalid',
+			username = NULL, display_name = NULL, avatar_url = NULL, bio = NULL, locale = NULL,
+			timezone = NULL, date_of_birth = NULL, status_reason = NULL, deleted_at = COALESCE(deleted_at, NOW()), erased_at = NOW()
+		WHERE id = $1
+		RETURNING ` + userColumns
+	erased, err := scanUser(tx.QueryRow(query, before.ID))
+	if err != nil {
+		return err
+	}
+	tombstone, err := json.Marshal(erased)
+	if err != nil {
+		return err
+	}
+
+	certificate.UserID = erased.ID
+	certificate.Scrubbed = map[string]int64{"users": 1}
+	params := erasureParams{userID: erased.ID, auth0ID: auth0ID, tombstone: string(tombstone)}
+	for _, step := range erasureSteps {
+		result, err := tx.Exec(step.query, step.args(params)...)
+		if err != nil {
+			return err
+		}
+		if certificate.Scrubbed[step.table], err = result.RowsAffected(); err != nil {
+			return err
+		}
+	}
+
+	if certificate.Remaining, err = remainingAuditCopies(tx, params); err != nil {
+		return err
+	}
+	certificate.Remaining = append(certificate.Remaining, erasureRetainedCopies...)
+
+	if err := insertUserEvent(tx, models.EventUserErased, erased, nil); err != nil {
+		return err
+	}
+
+	scrubbed, err := json.Marshal(certificate.Scrubbed)
+	if err != nil {
+		return err
+	}
+	remaining, err := json.Marshal(certificate.Remaining)
+	if err != nil {
+		return err
+	}
+	query = `
+		INSERT INTO erasure_certificates (id, user_id, requested_by, reason, scrubbed, remaining)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING completed_at
+	`
+	err = tx.QueryRow(query, certificate.ID, certificate.UserID, certificate.RequestedBy, certificate.Reason, scrubbed, remaining).Scan(&certificate.CompletedAt)
+	if err != nil {
+		return err
+	}
+
+	// Only the certificate is recorded; the audit entry must not carry the erased values.
+	details, err := json.Marshal(map[string]models.AuditChange{"certificate_id": {New: &certificate.ID}})
+	if err != nil {
+		return err
+	}
+	audit.Action = models.AuditUserErased
+	audit.TargetUserID = &erased.ID
+	audit.TargetAuth0ID = &erased.Auth0ID
+	audit.Changes = details
+	if err := appendAuditEvent(tx, &audit); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// remainingAuditCopies describes the audit entries that still hold the user's data after the
+// erasure steps: the Auth0 ID as actor or impersonator, which is kept for accountability.
+func remainingAuditCopies(tx *sql.Tx, p erasureParams) ([]string, error) {
+	var acted int64
+	query := `SELECT COUNT(*) FROM audit_log WHERE $1 IN (actor_subject, impersonator_subject)`
+	if err := tx.QueryRow(query, p.auth0ID).Scan(&acted); err != nil {
+		return nil, err
+	}
+
+	var remaining []string
+	if acted > 0 {
+		remaining = append(remaining, fmt.Sprintf("audit_log: %d entries keep the user's Auth0 ID as the actor or impersonator", acted))
+	}
+	return remaining, nil
+}
+
+// ✅ GetErasureCertificate - Retrieves the certificate recorded when a user was erased
+func (r *UserRepository) GetErasureCertificate(userID string) (*models.ErasureCertificate, error) {
+	var certificate models.ErasureCertificate
+	var scrubbed, remaining []byte
+	query := `SELECT id, user_id, requested_by, reason, scrubbed, remaining, completed_at FROM erasure_certificates WHERE user_id = $1`
+	err := r.DB.QueryRow(query, userID).Scan(&certificate.ID, &certificate.UserID, &certificate.RequestedBy,
+		&certificate.Reason, &scrubbed, &remaining, &certificate.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(scrubbed, &certificate.Scrubbed); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(remaining, &certificate.Remaining); err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
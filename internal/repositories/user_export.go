@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// ✅ ExportUsers - Walks the users table in (created_at, id) order with a server-side cursor,
+// calling fn for each row. Rows are fetched batchSize at a time, so a slow fn (e.g. a blocked
+// stream send) naturally throttles reads. Runs in a read-only snapshot until fn returns an
+// error or the table is exhausted.
+func (r *UserRepository) ExportUsers(ctx context.Context, after *UserCursor, includeDeleted bool, batchSize int, fn func(*models.User) error) error {
+	tx, err := r.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `DECLARE user_export NO SCROLL CURSOR FOR SELECT ` + userColumns + ` FROM users WHERE TRUE`
+	var args []interface{}
+	if !includeDeleted {
+		query += ` AND deleted_at IS NULL`
+	}
+	if after != nil {
+		query += ` AND (created_at, id) > ($1, $2)`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+	query += ` ORDER BY created_at, id`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf(`FETCH FORWARD %d FROM user_export`, batchSize)
+	for {
+		rows, err := tx.QueryContext(ctx, fetch)
+		if err != nil {
+			return err
+		}
+
+		var batch []*models.User
+		for rows.Next() {
+			user, err := scanUser(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			batch = append(batch, user)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, user := range batch {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// ✅ FindIdentityOwners - Maps lowercased emails and usernames already used by live accounts to their Auth0 IDs
+func (r *UserRepository) FindIdentityOwners(emails, usernameKeys []string) (emailOwners, usernameOwners map[string]string, err error) {
+	query := `
+		SELECT 'email', LOWER(email), auth0_id FROM users WHERE LOWER(email) = ANY($1) AND deleted_at IS NULL
+		UNION ALL
+		SELECT 'username', LOWER(username), auth0_id FROM users WHERE LOWER(username) = ANY($2) AND deleted_at IS NULL
+	`
+	rows, err := r.DB.Query(query, pq.Array(emails), pq.Array(usernameKeys))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	emailOwners = make(map[string]string)
+	usernameOwners = make(map[string]string)
+	for rows.Next() {
+		var kind, key, auth0ID string
+		if err := rows.Scan(&kind, &key, &auth0ID); err != nil {
+			return nil, nil, err
+		}
+		if kind == "email" {
+			emailOwners[key] = auth0ID
+		} else {
+			usernameOwners[key] = auth0ID
+		}
+	}
+	return emailOwners, usernameOwners, rows.Err()
+}
+
+// ✅ FindImportTargets - Maps the Auth0 IDs that already have an account, including soft-deleted ones, to that account
+func (r *UserRepository) FindImportTargets(auth0IDs []string) (map[string]*models.User, error) {
+	users, err := r.queryUsers(`SELECT `+userColumns+` FROM users WHERE auth0_id = ANY($1)`, pq.Array(auth0IDs))
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		targets[user.Auth0ID] = user
+	}
+	return targets, nil
+}
+
+// ✅ UpsertUsers - Inserts the users in one multi-row statement, updating the email of live accounts
+// that already exist for the same Auth0 ID, and records a UserCreated or UserUpdated event and an
+// audit entry for every row that changed. Existing usernames are kept when a row has none, and rows
+// that would change one, or that match a soft-deleted account, are left out.
+// Returns, per Auth0 ID, whether the row was inserted (true) or updated (false).
+func (r *UserRepository) UpsertUsers(users []*models.User, audit models.AuditEvent) (map[string]bool, error) {
+	if len(users) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	auth0IDs := make([]string, len(users))
+	values := make([]string, 0, len(users))
+	args := make([]interface{}, 0, len(users)*5)
+	for i, user := range users {
+		auth0IDs[i] = user.Auth0ID
+		n := i * 5
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5))
+		args = append(args, user.ID, user.Auth0ID, user.Email, user.Username, user.CreatedAt)
+	}
+
+	rows, err := tx.Query(`SELECT `+userColumns+` FROM users WHERE auth0_id = ANY($1) FOR UPDATE`, pq.Array(auth0IDs))
+	if err != nil {
+		return nil, err
+	}
+	existing, err := collectUsers(rows)
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[string]*models.User, len(existing))
+	for _, user := range existing {
+		before[user.Auth0ID] = user
+	}
+
+	query := `
+		INSERT INTO users (id, auth0_id, email, username, created_at)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT (auth0_id) DO UPDATE
+			SET email = EXCLUDED.email, username = COALESCE(EXCLUDED.username, users.username)
+			WHERE users.deleted_at IS NULL
+				AND (EXCLUDED.username IS NULL OR LOWER(EXCLUDED.username) = LOWER(users.username))
+		RETURNING ` + userColumns
+	rows, err = tx.Query(query, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	upserted, err := collectUsers(rows)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	inserted := make(map[string]bool, len(upserted))
+	for _, after := range upserted {
+		previous, found := before[after.Auth0ID]
+		inserted[after.Auth0ID] = !found
+
+		if !found {
+			err = insertUserEvent(tx, models.EventUserCreated, after, nil)
+		} else if changed := changedUserFields(previous, after); len(changed) > 0 {
+			err = insertUserEvent(tx, models.EventUserUpdated, after, changed)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := recordUserAudit(tx, audit, models.AuditUserImported, previous, after); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
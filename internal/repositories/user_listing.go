@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// DeletedFilter selects users by soft-delete state.
+type DeletedFilter int
+
+const (
+	ExcludeDeleted DeletedFilter = iota
+	OnlyDeleted
+	IncludeDeleted
+)
+
+// UserCursor is a keyset position in (created_at, id) order.
+type UserCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// ListUsersParams holds the filters and keyset position for ListUsers.
+type ListUsersParams struct {
+	CreatedAfter  *time.Time    // Inclusive lower bound on created_at
+	CreatedBefore *time.Time    // Exclusive upper bound on created_at
+	EmailDomain   string        // Exact email domain match (case-insensitive)
+	Deleted       DeletedFilter // Soft-delete state to include
+	After         *UserCursor   // Return rows strictly after this position
+	Descending    bool          // Newest first instead of oldest first
+	Limit         int
+}
+
+// ✅ ListUsers - Lists users matching the filters in keyset order on (created_at, id)
+func (r *UserRepository) ListUsers(params ListUsersParams) ([]*models.User, error) {
+	var conditions []string
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	switch params.Deleted {
+	case ExcludeDeleted:
+		conditions = append(conditions, "deleted_at IS NULL")
+	case OnlyDeleted:
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	}
+	if params.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= "+arg(*params.CreatedAfter))
+	}
+	if params.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < "+arg(*params.CreatedBefore))
+	}
+	if params.EmailDomain != "" {
+		conditions = append(conditions, "SPLIT_PART(LOWER(email), '@', 2) = LOWER("+arg(params.EmailDomain)+")")
+	}
+
+	comparison, direction := ">", "ASC"
+	if params.Descending {
+		comparison, direction = "<", "DESC"
+	}
+	if params.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) %s (%s, %s)",
+			comparison, arg(params.After.CreatedAt), arg(params.After.ID)))
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(` ORDER BY created_at %s, id %s LIMIT %s`, direction, direction, arg(params.Limit))
+
+	return r.queryUsers(query, args...)
+}
+
+// ✅ SearchUsers - Finds live users whose username or email starts with, or is similar to, the query.
+// Prefix matches rank first, then trigram similarity.
+func (r *UserRepository) SearchUsers(term string, offset, limit int) ([]*models.User, error) {
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE deleted_at IS NULL
+			AND (LOWER(username) LIKE $2 OR LOWER(email) LIKE $2 OR LOWER(username) % $1 OR LOWER(email) % $1)
+		ORDER BY
+			(LOWER(username) LIKE $2 OR LOWER(email) LIKE $2) DESC,
+			GREATEST(COALESCE(similarity(LOWER(username), $1), 0), similarity(LOWER(email), $1)) DESC,
+			created_at, id
+		OFFSET $3 LIMIT $4
+	`
+	term = strings.ToLower(term)
+	return r.queryUsers(query, term, escapeLike(term)+"%", offset, limit)
+}
+
+// queryUsers runs a query selecting userColumns and scans every row.
+func (r *UserRepository) queryUsers(query string, args ...interface{}) ([]*models.User, error) {
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return collectUsers(rows)
+}
+
+// collectUsers scans and closes rows selected with userColumns.
+func collectUsers(rows *sql.Rows) ([]*models.User, error) {
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// escapeLike escapes LIKE wildcards so the term matches literally.
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
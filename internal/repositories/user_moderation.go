@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+// liftBatchSize bounds how many expired suspensions LiftExpiredSuspensions lifts per transaction.
+const liftBatchSize = 100
+
+// ✅ SetUserStatus - Sets the moderation status of a live user, recording a UserUpdated event and an
+// audit entry under action when it changed. Returns the updated user.
+func (r *UserRepository) SetUserStatus(auth0ID string, change models.UserStatusChange, action string, audit models.AuditEvent) (*models.User, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	before, err := scanUser(tx.QueryRow(`SELECT `+userColumns+` FROM users WHERE auth0_id = $1 AND deleted_at IS NULL FOR UPDATE`, auth0ID))
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE users SET status = $1, status_reason = $2, status_expires_at = $3, status_changed_by = $4, status_changed_at = NOW()
+		WHERE id = $5
+		RETURNING ` + userColumns
+	after, err := scanUser(tx.QueryRow(query, change.Status, change.Reason, change.ExpiresAt, change.ChangedBy, before.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	if changed := changedUserFields(before, after); len(changed) > 0 {
+		if err := insertUserEvent(tx, models.EventUserUpdated, after, changed); err != nil {
+			return nil, err
+		}
+	}
+	if err := recordUserAudit(tx, audit, action, before, after); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// ✅ ListRestrictedUsers - Lists live users that are suspended or banned, including suspensions
+// that expired but were not lifted yet
+func (r *UserRepository) ListRestrictedUsers() ([]*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE status IN ('suspended', 'banned') AND deleted_at IS NULL`
+	return r.queryUsers(query)
+}
+
+// ✅ LiftExpiredSuspensions - Reactivates up to liftBatchSize users whose suspension expired, recording
+// a UserUpdated event and an audit entry for each. Returns the lifted users.
+func (r *UserRepository) LiftExpiredSuspensions(audit models.AuditEvent) ([]*models.User, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED lets several instances lift in parallel, and skips users a moderator is changing.
+	rows, err := tx.Query(`
+		SELECT `+userColumns+` FROM users
+		WHERE status = 'suspended' AND status_expires_at <= NOW()
+		ORDER BY status_expires_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`, liftBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	expired, err := collectUsers(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	lifted := make([]*models.User, 0, len(expired))
+	for _, before := range expired {
+		query := `
+			UPDATE users SET status = 'active', status_reason = NULL, status_expires_at = NULL,
+				status_changed_by = NULL, status_changed_at = NOW()
+			WHERE id = $1
+			RETURNING ` + userColumns
+		after, err := scanUser(tx.QueryRow(query, before.ID))
+		if err != nil {
+			return nil, err
+		}
+		if err := insertUserEvent(tx, models.EventUserUpdated, after, changedUserFields(before, after)); err != nil {
+			return nil, err
+		}
+		if err := recordUserAudit(tx, audit, models.AuditUserSuspensionExpired, before, after); err != nil {
+			return nil, err
+		}
+		lifted = append(lifted, after)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return lifted, nil
+}
@@ -2,7 +2,12 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
 )
 
@@ -15,47 +20,249 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	return &UserRepository{DB: db}
 }
 
-// ✅ CreateUser - Inserts a new user into the database
-func (r *UserRepository) CreateUser(user *models.User) error {
+// ✅ CreateUser - Inserts a new user into the database, recording a UserCreated event and an audit entry.
+// A soft-deleted account with the same Auth0 ID is not reactivated: ErrUserDeleted is returned instead.
+func (r *UserRepository) CreateUser(user *models.User, audit models.AuditEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO users (id, auth0_id, email, username, created_at)
 		VALUES ($1, $2, $3, $4, $5)
-	`
-	_, err := r.DB.Exec(query, user.ID, user.Auth0ID, user.Email, user.Username, user.CreatedAt)
-	return err
+		ON CONFLICT (auth0_id) DO NOTHING
+		RETURNING ` + userColumns
+	created, err := scanUser(tx.QueryRow(query, user.ID, user.Auth0ID, user.Email, user.Username, user.CreatedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		var deleted bool
+		if err := tx.QueryRow(`SELECT deleted_at IS NOT NULL FROM users WHERE auth0_id = $1`, user.Auth0ID).Scan(&deleted); err != nil {
+			return err
+		}
+		if deleted {
+			return ErrUserDeleted
+		}
+		return ErrAuth0IDTaken
+	}
+	if err != nil {
+		return translateError(err)
+	}
+
+	if err := insertUserEvent(tx, models.EventUserCreated, created, nil); err != nil {
+		return err
+	}
+	if err := recordUserAudit(tx, audit, models.AuditUserCreated, nil, created); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	*user = *created
+	return nil
 }
 
-// ✅ GetUser - Retrieves a user by their Auth0 ID
-func (r *UserRepository) GetUser(auth0ID string) (*models.User, error) {
-	query := `SELECT id, auth0_id, email, username, created_at FROM users WHERE auth0_id = $1`
-	row := r.DB.QueryRow(query, auth0ID)
+// userColumns is the column list scanned by scanUser. It must select from (or return) the
+// users table unaliased, since the roles subquery refers to users.id.
+const userColumns = `id, auth0_id, email, username, created_at, deleted_at,
+	display_name, avatar_url, bio, locale, timezone, date_of_birth, updated_at, last_login_at, erased_at,
+	status, status_reason, status_expires_at, status_changed_by, status_changed_at,
+	ARRAY(SELECT role FROM user_roles WHERE user_roles.user_id = users.id ORDER BY role)`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
+// scanUser reads a row selected with userColumns.
+func scanUser(row rowScanner) (*models.User, error) {
 	var user models.User
-	err := row.Scan(&user.ID, &user.Auth0ID, &user.Email, &user.Username, &user.CreatedAt)
+	err := row.Scan(&user.ID, &user.Auth0ID, &user.Email, &user.Username, &user.CreatedAt, &user.DeletedAt,
+		&user.DisplayName, &user.AvatarURL, &user.Bio, &user.Locale, &user.Timezone, &user.DateOfBirth,
+		&user.UpdatedAt, &user.LastLoginAt, &user.ErasedAt,
+		&user.Status, &user.StatusReason, &user.StatusExpiresAt, &user.StatusChangedBy, &user.StatusChangedAt,
+		pq.Array(&user.Roles))
 	if err != nil {
 		return nil, err
 	}
-
 	return &user, nil
 }
 
-// ✅ UpdateUsername - Updates the username for a user
-func (r *UserRepository) UpdateUsername(auth0ID, username string) error {
-	query := `UPDATE users SET username = $1 WHERE auth0_id = $2`
-	_, err := r.DB.Exec(query, username, auth0ID)
-	return err
+// ✅ GetUser - Retrieves a user by their Auth0 ID
+func (r *UserRepository) GetUser(auth0ID string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE auth0_id = $1 AND deleted_at IS NULL`
+	return scanUser(r.DB.QueryRow(query, auth0ID))
+}
+
+// ✅ GetUserByID - Retrieves a user by their database ID
+func (r *UserRepository) GetUserByID(id string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND deleted_at IS NULL`
+	return scanUser(r.DB.QueryRow(query, id))
+}
+
+// ✅ GetUserByEmail - Retrieves a user by email (case-insensitive, uses users_email_lower_key)
+func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL`
+	return scanUser(r.DB.QueryRow(query, email))
+}
+
+// ✅ GetUsersByIDs - Retrieves all users whose database ID is in ids (all must be valid UUIDs)
+func (r *UserRepository) GetUsersByIDs(ids []string) ([]*models.User, error) {
+	return r.getUsersWhereAny(`id = ANY($1::uuid[])`, ids)
+}
+
+// ✅ GetUsersByAuth0IDs - Retrieves all users whose Auth0 ID is in auth0IDs
+func (r *UserRepository) GetUsersByAuth0IDs(auth0IDs []string) ([]*models.User, error) {
+	return r.getUsersWhereAny(`auth0_id = ANY($1)`, auth0IDs)
+}
+
+// ✅ GetUsersByUsernames - Retrieves all users whose lowercased username is in usernameKeys
+func (r *UserRepository) GetUsersByUsernames(usernameKeys []string) ([]*models.User, error) {
+	return r.getUsersWhereAny(`LOWER(username) = ANY($1)`, usernameKeys)
+}
+
+// getUsersWhereAny runs a single lookup whose condition matches against the $1 array.
+// condition must be a trusted SQL fragment.
+func (r *UserRepository) getUsersWhereAny(condition string, values []string) ([]*models.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE deleted_at IS NULL AND ` + condition
+	return r.queryUsers(query, pq.Array(values))
+}
+
+// ✅ UpdateUsername - Updates the username for a user, recording the change in username_history,
+// as a UserUpdated event and in the audit log. A change within cooldown of the account's previous
+// one fails with a *UsernameCooldownError; the check runs with the user row locked, so concurrent
+// changes cannot both pass it.
+func (r *UserRepository) UpdateUsername(auth0ID, username string, cooldown time.Duration, audit models.AuditEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := scanUser(tx.QueryRow(`SELECT `+userColumns+` FROM users WHERE auth0_id = $1 AND deleted_at IS NULL FOR UPDATE`, auth0ID))
+	if err != nil {
+		return err
+	}
+
+	// Case-only edits keep the same handle, so they are neither a change nor limited by the cooldown.
+	renamed := before.Username == nil || !strings.EqualFold(*before.Username, username)
+	if renamed && before.Username != nil {
+		var lastChange sql.NullTime
+		query := `SELECT MAX(changed_at) FROM username_history WHERE user_id = $1 AND old_username IS NOT NULL`
+		if err := tx.QueryRow(query, before.ID).Scan(&lastChange); err != nil {
+			return err
+		}
+		if nextChange := lastChange.Time.Add(cooldown); lastChange.Valid && time.Now().Before(nextChange) {
+			return &UsernameCooldownError{NextChange: nextChange}
+		}
+	}
+
+	after, err := scanUser(tx.QueryRow(`UPDATE users SET username = $1 WHERE id = $2 RETURNING `+userColumns, username, before.ID))
+	if err != nil {
+		return translateError(err)
+	}
+
+	if renamed {
+		query := `INSERT INTO username_history (user_id, old_username, new_username) VALUES ($1, $2, $3)`
+		if _, err := tx.Exec(query, before.ID, before.Username, username); err != nil {
+			return err
+		}
+	}
+
+	if changed := changedUserFields(before, after); len(changed) > 0 {
+		if err := insertUserEvent(tx, models.EventUserUpdated, after, changed); err != nil {
+			return err
+		}
+	}
+	if err := recordUserAudit(tx, audit, models.AuditUserUsernameChanged, before, after); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// ✅ UpdateUserEmail - Updates the email for a user
-func (r *UserRepository) UpdateUserEmail(auth0ID, email string) error {
-	query := `UPDATE users SET email = $1 WHERE auth0_id = $2`
-	_, err := r.DB.Exec(query, email, auth0ID)
-	return err
+// ✅ UpdateProfile - Updates the email and profile fields set on the update, recording a UserUpdated
+// event and an audit entry when anything actually changed
+func (r *UserRepository) UpdateProfile(auth0ID string, update models.UserProfileUpdate, audit models.AuditEvent) error {
+	var assignments []string
+	var args []interface{}
+	set := func(column string, value *string, expr string) {
+		if value == nil {
+			return
+		}
+		args = append(args, *value)
+		assignments = append(assignments, fmt.Sprintf("%s = "+expr, column, len(args)))
+	}
+
+	set("email", update.Email, "$%d")
+	set("display_name", update.DisplayName, "NULLIF($%d, '')")
+	set("avatar_url", update.AvatarURL, "NULLIF($%d, '')")
+	set("bio", update.Bio, "NULLIF($%d, '')")
+	set("locale", update.Locale, "NULLIF($%d, '')")
+	set("timezone", update.Timezone, "NULLIF($%d, '')")
+	set("date_of_birth", update.DateOfBirth, "NULLIF($%d, '')::date")
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := scanUser(tx.QueryRow(`SELECT `+userColumns+` FROM users WHERE auth0_id = $1 AND deleted_at IS NULL FOR UPDATE`, auth0ID))
+	if err != nil {
+		return err
+	}
+
+	args = append(args, before.ID)
+	query := fmt.Sprintf(`UPDATE users SET %s WHERE id = $%d RETURNING %s`,
+		strings.Join(assignments, ", "), len(args), userColumns)
+
+	after, err := scanUser(tx.QueryRow(query, args...))
+	if err != nil {
+		return translateError(err)
+	}
+
+	if changed := changedUserFields(before, after); len(changed) > 0 {
+		if err := insertUserEvent(tx, models.EventUserUpdated, after, changed); err != nil {
+			return err
+		}
+	}
+	if err := recordUserAudit(tx, audit, models.AuditUserUpdated, before, after); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// ✅ DeleteUser - Removes a user by their Auth0 ID
-func (r *UserRepository) DeleteUser(auth0ID string) error {
-	query := `DELETE FROM users WHERE auth0_id = $1`
-	_, err := r.DB.Exec(query, auth0ID)
-	return err
+// ✅ DeleteUser - Soft-deletes a user by their Auth0 ID, releasing their email and username,
+// and records a UserDeleted event and an audit entry
+func (r *UserRepository) DeleteUser(auth0ID string, audit models.AuditEvent) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET deleted_at = NOW() WHERE auth0_id = $1 AND deleted_at IS NULL RETURNING ` + userColumns
+	deleted, err := scanUser(tx.QueryRow(query, auth0ID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := insertUserEvent(tx, models.EventUserDeleted, deleted, nil); err != nil {
+		return err
+	}
+	before := *deleted
+	before.DeletedAt = nil
+	if err := recordUserAudit(tx, audit, models.AuditUserDeleted, &before, deleted); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
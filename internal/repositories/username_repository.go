@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type UsernameRepository struct {
+	DB *sql.DB
+}
+
+// NewUsernameRepository creates a new instance of UsernameRepository.
+func NewUsernameRepository(db *sql.DB) *UsernameRepository {
+	return &UsernameRepository{DB: db}
+}
+
+// ✅ GetUsernameOwner - Returns the Auth0 ID of the account using the username (case-insensitive), or "" if free
+func (r *UsernameRepository) GetUsernameOwner(username string) (string, error) {
+	query := `SELECT auth0_id FROM users WHERE LOWER(username) = LOWER($1) AND deleted_at IS NULL`
+
+	var auth0ID string
+	err := r.DB.QueryRow(query, username).Scan(&auth0ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return auth0ID, err
+}
+
+// ✅ FindUnavailableUsernames - Returns the lowercase keys among the candidates that are in use,
+// reserved, or were retired after heldSince by an account that still exists
+func (r *UsernameRepository) FindUnavailableUsernames(keys []string, heldSince time.Time) (map[string]bool, error) {
+	query := `
+		SELECT LOWER(username) FROM users WHERE LOWER(username) = ANY($1) AND deleted_at IS NULL
+		UNION
+		SELECT username_key FROM username_reservations WHERE username_key = ANY($1) AND expires_at > NOW()
+		UNION
+		SELECT LOWER(h.old_username)
+		FROM username_history h
+		JOIN users u ON u.id = h.user_id
+		WHERE LOWER(h.old_username) = ANY($1) AND h.changed_at > $2 AND u.deleted_at IS NULL
+	`
+	rows, err := r.DB.Query(query, pq.Array(keys), heldSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unavailable := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		unavailable[key] = true
+	}
+	return unavailable, rows.Err()
+}
+
+// ✅ GetReservationHolder - Returns the Auth0 ID holding an unexpired reservation on the username, or "" if none
+func (r *UsernameRepository) GetReservationHolder(usernameKey string) (string, error) {
+	query := `SELECT auth0_id FROM username_reservations WHERE username_key = $1 AND expires_at > NOW()`
+
+	var auth0ID string
+	err := r.DB.QueryRow(query, usernameKey).Scan(&auth0ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return auth0ID, err
+}
+
+// ✅ ReserveUsername - Holds the username for the account until expiresAt.
+// Returns false if another account holds an unexpired reservation.
+func (r *UsernameRepository) ReserveUsername(usernameKey, username, auth0ID string, expiresAt time.Time) (bool, error) {
+	query := `
+		INSERT INTO username_reservations (username_key, username, auth0_id, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (username_key) DO UPDATE
+			SET username = EXCLUDED.username, auth0_id = EXCLUDED.auth0_id, expires_at = EXCLUDED.expires_at
+			WHERE username_reservations.auth0_id = EXCLUDED.auth0_id OR username_reservations.expires_at <= NOW()
+	`
+	result, err := r.DB.Exec(query, usernameKey, username, auth0ID, expiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ✅ ReleaseReservations - Drops the account's reservation on the username and any expired reservations
+func (r *UsernameRepository) ReleaseReservations(usernameKey, auth0ID string) error {
+	query := `
+		DELETE FROM username_reservations
+		WHERE (username_key = $1 AND auth0_id = $2) OR expires_at <= NOW()
+	`
+	_, err := r.DB.Exec(query, usernameKey, auth0ID)
+	return err
+}
+
+// ✅ GetRetiredUsernameOwner - Returns the Auth0 ID of the account that most recently retired the
+// username after since, or "" if none
+func (r *UsernameRepository) GetRetiredUsernameOwner(username string, since time.Time) (string, error) {
+	query := `
+		SELECT u.auth0_id
+		FROM username_history h
+		JOIN users u ON u.id = h.user_id
+		WHERE LOWER(h.old_username) = LOWER($1) AND h.changed_at > $2 AND u.deleted_at IS NULL
+		ORDER BY h.changed_at DESC
+		LIMIT 1
+	`
+
+	var auth0ID string
+	err := r.DB.QueryRow(query, username, since).Scan(&auth0ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return auth0ID, err
+}
@@ -0,0 +1,300 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+)
+
+type WebhookRepository struct {
+	DB *sql.DB
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository.
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{DB: db}
+}
+
+// webhookSubscriptionColumns is the column list scanned by scanWebhookSubscription.
+const webhookSubscriptionColumns = `id, url, secret, event_types, active, created_by, created_at, updated_at`
+
+func scanWebhookSubscription(row rowScanner) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, pq.Array(&sub.EventTypes), &sub.Active, &sub.CreatedBy, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ✅ CreateSubscription - Registers a webhook endpoint
+func (r *WebhookRepository) CreateSubscription(sub *models.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (id, url, secret, event_types, active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, updated_at
+	`
+	return r.DB.QueryRow(query, sub.ID, sub.URL, sub.Secret, pq.Array(sub.EventTypes), sub.Active, sub.CreatedBy).Scan(&sub.CreatedAt, &sub.UpdatedAt)
+}
+
+// ✅ ListSubscriptions - Returns every webhook subscription, oldest first
+func (r *WebhookRepository) ListSubscriptions() ([]*models.WebhookSubscription, error) {
+	rows, err := r.DB.Query(`SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*models.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// ✅ UpdateSubscription - Changes the fields set on the update and returns the subscription
+func (r *WebhookRepository) UpdateSubscription(id string, update models.WebhookSubscriptionUpdate) (*models.WebhookSubscription, error) {
+	var assignments []string
+	var args []interface{}
+	set := func(column string, value interface{}) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if update.URL != nil {
+		set("url", *update.URL)
+	}
+	if update.EventTypes != nil {
+		set("event_types", pq.Array(update.EventTypes))
+	}
+	if update.Active != nil {
+		set("active", *update.Active)
+	}
+	if len(assignments) == 0 {
+		return scanWebhookSubscription(r.DB.QueryRow(`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, id))
+	}
+
+	args = append(args, id)
+	query := fmt.Sprintf(`UPDATE webhook_subscriptions SET %s WHERE id = $%d RETURNING %s`,
+		strings.Join(assignments, ", "), len(args), webhookSubscriptionColumns)
+	return scanWebhookSubscription(r.DB.QueryRow(query, args...))
+}
+
+// ✅ DeleteSubscription - Removes a subscription together with its deliveries
+func (r *WebhookRepository) DeleteSubscription(id string) error {
+	result, err := r.DB.Exec(`DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
+// ✅ EnqueueDeliveries - Queues the event for every active subscription interested in its type.
+// Enqueuing the same event twice is a no-op. Returns the number of deliveries queued.
+func (r *WebhookRepository) EnqueueDeliveries(eventID int64, eventType string, body []byte) (int64, error) {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3 FROM webhook_subscriptions
+		WHERE active AND (cardinality(event_types) = 0 OR $2 = ANY(event_types))
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+	result, err := r.DB.Exec(query, eventID, eventType, body)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DueWebhookDelivery is a delivery claimed for sending, with its endpoint.
+type DueWebhookDelivery struct {
+	models.WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// ✅ ClaimDueDeliveries - Leases up to limit pending deliveries to active subscriptions that are due,
+// pushing their next attempt back by lease so no other dispatcher picks them up while they are being
+// sent. Deliveries to deactivated subscriptions stay pending until the subscription is reactivated.
+func (r *WebhookRepository) ClaimDueDeliveries(limit int, lease time.Duration) ([]*DueWebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND s.active AND d.id IN (
+			SELECT pd.id FROM webhook_deliveries pd
+			JOIN webhook_subscriptions ps ON ps.id = pd.subscription_id
+			WHERE pd.status = 'pending' AND pd.next_attempt_at <= NOW() AND ps.active
+			ORDER BY pd.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF pd SKIP LOCKED
+		)
+		RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret
+	`
+	rows, err := r.DB.Query(query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []*DueWebhookDelivery
+	for rows.Next() {
+		var d DueWebhookDelivery
+		err := rows.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Attempts, &d.URL, &d.Secret)
+		if err != nil {
+			return nil, err
+		}
+		due = append(due, &d)
+	}
+	return due, rows.Err()
+}
+
+// ✅ MarkDelivered - Records a successful attempt
+func (r *WebhookRepository) MarkDelivered(id int64, statusCode int) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, last_attempt_at = NOW(), delivered_at = NOW(),
+			last_status_code = $2, last_error = NULL
+		WHERE id = $1
+	`
+	_, err := r.DB.Exec(query, id, statusCode)
+	return err
+}
+
+// ✅ ScheduleRetry - Records a failed attempt and when to try again
+func (r *WebhookRepository) ScheduleRetry(id int64, statusCode *int, lastError string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, last_attempt_at = NOW(), last_status_code = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`
+	_, err := r.DB.Exec(query, id, statusCode, lastError, nextAttemptAt)
+	return err
+}
+
+// ✅ MarkDead - Records the final failed attempt and moves the delivery to the dead-letter table
+func (r *WebhookRepository) MarkDead(id int64, statusCode *int, lastError string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'dead', attempts = attempts + 1, last_attempt_at = NOW(), last_status_code = $2, last_error = $3
+		WHERE id = $1
+	`
+	if _, err := tx.Exec(query, id, statusCode, lastError); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO webhook_dead_letters (delivery_id, subscription_id, event_id, payload, attempts, last_error)
+		SELECT id, subscription_id, event_id, payload, attempts, last_error FROM webhook_deliveries WHERE id = $1
+		ON CONFLICT (delivery_id) DO UPDATE SET attempts = EXCLUDED.attempts, last_error = EXCLUDED.last_error, created_at = NOW()
+	`
+	if _, err := tx.Exec(query, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ✅ Redeliver - Requeues a delivered or dead delivery for immediate sending, clearing its dead letter
+func (r *WebhookRepository) Redeliver(id int64) (*models.WebhookDelivery, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL
+		WHERE id = $1
+		RETURNING ` + webhookDeliveryColumns
+	delivery, err := scanWebhookDelivery(tx.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(`DELETE FROM webhook_dead_letters WHERE delivery_id = $1`, id); err != nil {
+		return nil, err
+	}
+	return delivery, tx.Commit()
+}
+
+// WebhookDeliveryFilter selects deliveries for the delivery log.
+type WebhookDeliveryFilter struct {
+	SubscriptionID string // Optional
+	Status         string // Optional: pending, delivered or dead
+	BeforeID       int64  // Keyset cursor; 0 starts at the newest
+	Limit          int
+}
+
+// webhookDeliveryColumns is the column list scanned by scanWebhookDelivery.
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts,
+	next_attempt_at, last_attempt_at, last_status_code, last_error, delivered_at, created_at`
+
+func scanWebhookDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &d.LastStatusCode, &d.LastError, &d.DeliveredAt, &d.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ✅ ListDeliveries - Returns the delivery log, newest first
+func (r *WebhookRepository) ListDeliveries(filter WebhookDeliveryFilter) ([]*models.WebhookDelivery, error) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.SubscriptionID != "" {
+		add("subscription_id = $%d", filter.SubscriptionID)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.BeforeID > 0 {
+		add("id < $%d", filter.BeforeID)
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
@@ -11,13 +11,13 @@ import (
 )
 
 // RunGRPCServer starts the gRPC server.
-func RunGRPCServer(port string, handler pb.UserServiceServer) error {
+func RunGRPCServer(port string, handler pb.UserServiceServer, opts ...grpc.ServerOption) error {
 	listener, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
 	}
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(opts...)
 	pb.RegisterUserServiceServer(server, handler)
 
 	// Enable gRPC Reflection
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+)
+
+// requirePermission rejects callers whose token lacks the permission.
+func requirePermission(ctx context.Context, permission string) error {
+	claims := auth.FromContext(ctx)
+	if claims == nil {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if !claims.HasPermission(permission) {
+		return status.Errorf(codes.PermissionDenied, "requires the %s permission", permission)
+	}
+	return nil
+}
+
+// resolveSubject returns the Auth0 ID a self-service call acts on. Callers default to themselves
+// and may only name another user when they hold the permission. Unauthenticated calls are always
+// rejected, even when AUTH_REQUIRED is off, since nothing ties them to the requested user.
+func resolveSubject(ctx context.Context, auth0ID, permission string) (string, error) {
+	claims := auth.FromContext(ctx)
+	if claims == nil {
+		return "", status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if auth0ID == "" || auth0ID == claims.Subject {
+		return claims.Subject, nil
+	}
+	if !claims.HasPermission(permission) {
+		return "", status.Errorf(codes.PermissionDenied, "acting on another user requires the %s permission", permission)
+	}
+	return auth0ID, nil
+}
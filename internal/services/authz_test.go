@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+)
+
+// callerContext returns a context authenticated as subject holding the permissions.
+func callerContext(subject string, permissions ...string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Claims{Subject: subject, Permissions: permissions})
+}
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), codes.Unauthenticated},
+		{"without the permission", callerContext("auth0|jane"), codes.PermissionDenied},
+		{"with the permission", callerContext("auth0|jane", auth.PermissionReadUserEmails), codes.OK},
+		{"admin", callerContext("auth0|admin", auth.PermissionAdmin), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := requirePermission(tt.ctx, auth.PermissionReadUserEmails); status.Code(err) != tt.wantCode {
+				t.Errorf("requirePermission() = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestResolveSubject(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		auth0ID  string
+		want     string
+		wantCode codes.Code
+	}{
+		{name: "unauthenticated", ctx: context.Background(), auth0ID: "auth0|jane", wantCode: codes.Unauthenticated},
+		{name: "defaults to the caller", ctx: callerContext("auth0|jane"), want: "auth0|jane"},
+		{name: "names the caller", ctx: callerContext("auth0|jane"), auth0ID: "auth0|jane", want: "auth0|jane"},
+		{name: "names another user", ctx: callerContext("auth0|jane"), auth0ID: "auth0|john", wantCode: codes.PermissionDenied},
+		{name: "names another user with the permission", ctx: callerContext("auth0|hook", auth.PermissionRecordLogins), auth0ID: "auth0|john", want: "auth0|john"},
+		{name: "admin names another user", ctx: callerContext("auth0|admin", auth.PermissionAdmin), auth0ID: "auth0|john", want: "auth0|john"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSubject(tt.ctx, tt.auth0ID, auth.PermissionRecordLogins)
+			if status.Code(err) != tt.wantCode || got != tt.want {
+				t.Errorf("resolveSubject() = %q, %v, want %q, %v", got, err, tt.want, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodically calls task every interval until ctx is cancelled. A non-positive interval disables it.
+func runPeriodically(ctx context.Context, interval time.Duration, task func()) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			task()
+		}
+	}
+}
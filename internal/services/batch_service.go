@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// ✅ BatchGetUsers - Resolves many keys with one query, preserving request order
+func (s *UserService) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	log.Printf("🔹 Batch retrieving users | KeyType: %s | Count: %d", req.KeyType, len(req.Keys))
+
+	if len(req.Keys) > s.BatchLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d keys can be requested at once", s.BatchLimit)
+	}
+
+	// normalize maps a requested key onto the value compared against the database.
+	var normalize func(string) (string, bool)
+	var fetch func([]string) ([]*models.User, error)
+	var keyOf func(*models.User) string
+
+	switch req.KeyType {
+	case pb.UserKeyType_USER_KEY_TYPE_ID:
+		normalize = func(key string) (string, bool) { return key, uuid.Validate(key) == nil }
+		fetch = s.Repo.GetUsersByIDs
+		keyOf = func(user *models.User) string { return user.ID }
+	case pb.UserKeyType_USER_KEY_TYPE_AUTH0_ID:
+		normalize = func(key string) (string, bool) { return key, key != "" }
+		fetch = s.Repo.GetUsersByAuth0IDs
+		keyOf = func(user *models.User) string { return user.Auth0ID }
+	case pb.UserKeyType_USER_KEY_TYPE_USERNAME:
+		normalize = func(key string) (string, bool) { return utils.UsernameKey(key), key != "" }
+		fetch = s.Repo.GetUsersByUsernames
+		keyOf = func(user *models.User) string { return utils.UsernameKey(derefString(user.Username)) }
+	default:
+		return nil, status.Error(codes.InvalidArgument, "key_type is required")
+	}
+
+	lookups := make([]string, len(req.Keys))
+	var values []string
+	seen := make(map[string]bool)
+	for i, key := range req.Keys {
+		value, ok := normalize(key)
+		if !ok {
+			continue
+		}
+		lookups[i] = value
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+
+	found := make(map[string]*models.User)
+	if len(values) > 0 {
+		users, err := fetch(values)
+		if err != nil {
+			log.Printf("❌ Failed to batch retrieve users: %v", err)
+			return nil, err
+		}
+		for _, user := range users {
+			found[keyOf(user)] = user
+		}
+	}
+
+	resp := &pb.BatchGetUsersResponse{Results: make([]*pb.BatchGetUsersResult, len(req.Keys))}
+	for i, key := range req.Keys {
+		result := &pb.BatchGetUsersResult{Key: key}
+		if user, ok := found[lookups[i]]; ok && lookups[i] != "" {
+			result.Found = true
+			result.User = userResponseFor(ctx, user)
+		}
+		resp.Results[i] = result
+	}
+
+	log.Printf("✅ Batch retrieved %d of %d users", len(found), len(req.Keys))
+	return resp, nil
+}
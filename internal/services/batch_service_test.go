@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestBatchGetUsersRejectsInvalidRequests(t *testing.T) {
+	s := &UserService{BatchLimit: 2}
+
+	tests := []struct {
+		name string
+		req  *pb.BatchGetUsersRequest
+	}{
+		{"no key type", &pb.BatchGetUsersRequest{Keys: []string{"auth0|jane"}}},
+		{"too many keys", &pb.BatchGetUsersRequest{KeyType: pb.UserKeyType_USER_KEY_TYPE_AUTH0_ID, Keys: []string{"a", "b", "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.BatchGetUsers(context.Background(), tt.req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("BatchGetUsers() error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestBatchGetUsersSkipsUnusableKeys(t *testing.T) {
+	// None of the keys can match a user, so the repository is never queried.
+	s := &UserService{BatchLimit: 10}
+
+	tests := []struct {
+		keyType pb.UserKeyType
+		keys    []string
+	}{
+		{pb.UserKeyType_USER_KEY_TYPE_ID, []string{"42", "", "not-a-uuid"}},
+		{pb.UserKeyType_USER_KEY_TYPE_AUTH0_ID, []string{"", ""}},
+		{pb.UserKeyType_USER_KEY_TYPE_USERNAME, []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.keyType.String(), func(t *testing.T) {
+			resp, err := s.BatchGetUsers(context.Background(), &pb.BatchGetUsersRequest{KeyType: tt.keyType, Keys: tt.keys})
+			if err != nil {
+				t.Fatalf("BatchGetUsers() = %v", err)
+			}
+			if len(resp.Results) != len(tt.keys) {
+				t.Fatalf("BatchGetUsers() returned %d results, want %d", len(resp.Results), len(tt.keys))
+			}
+			for i, result := range resp.Results {
+				if result.Key != tt.keys[i] || result.Found || result.User != nil {
+					t.Errorf("result %d = %+v, want key %q not found", i, result, tt.keys[i])
+				}
+			}
+		})
+	}
+}
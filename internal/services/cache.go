@@ -0,0 +1,96 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// refreshingCache holds a snapshot loaded from the database and reloads it once it is older than
+// maxAge. Callers that find it stale share a single load, which runs outside the lock so calls
+// that only read are not held up. Snapshots are never modified in place: local changes replace
+// them through update, and a load that started before such a change is discarded, since it may
+// have missed it.
+type refreshingCache[T any] struct {
+	maxAge time.Duration
+	load   func() (T, error)
+	loads  singleflight.Group
+
+	mu          sync.Mutex
+	value       T
+	refreshedAt time.Time
+	generation  uint64 // Bumped on every update and invalidation
+}
+
+// newRefreshingCache creates a cache that is loaded on first use.
+func newRefreshingCache[T any](maxAge time.Duration, load func() (T, error)) *refreshingCache[T] {
+	return &refreshingCache[T]{maxAge: maxAge, load: load}
+}
+
+// get returns the current snapshot, reloading it first when it is stale.
+func (c *refreshingCache[T]) get() (T, error) {
+	c.mu.Lock()
+	value, fresh := c.value, !c.refreshedAt.IsZero() && time.Since(c.refreshedAt) < c.maxAge
+	c.mu.Unlock()
+	if fresh {
+		return value, nil
+	}
+
+	loaded, err, _ := c.loads.Do("", func() (interface{}, error) {
+		return c.reload()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return loaded.(T), nil
+}
+
+// reload loads a new snapshot and keeps it unless the cache changed while it was loading. A
+// discarded load is retried while the cache has no current snapshot to fall back on, e.g. right
+// after an invalidation.
+func (c *refreshingCache[T]) reload() (T, error) {
+	for {
+		c.mu.Lock()
+		generation := c.generation
+		c.mu.Unlock()
+
+		value, err := c.load()
+		if err != nil {
+			return value, err
+		}
+
+		c.mu.Lock()
+		if c.generation == generation {
+			c.value = value
+			c.refreshedAt = time.Now()
+			c.generation++
+			c.mu.Unlock()
+			return value, nil
+		}
+		if !c.refreshedAt.IsZero() {
+			value = c.value
+			c.mu.Unlock()
+			return value, nil
+		}
+		c.mu.Unlock()
+	}
+}
+
+// update replaces the snapshot with change(snapshot) right away instead of waiting for the next
+// reload. change must return a new snapshot rather than modify the one it is given.
+func (c *refreshingCache[T]) update(change func(T) T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value = change(c.value)
+	c.generation++
+}
+
+// invalidate makes the next get reload the snapshot.
+func (c *refreshingCache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshedAt = time.Time{}
+	c.generation++
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefreshingCacheSharesOneLoad(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	cache := newRefreshingCache(time.Hour, func() (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = cache.get()
+		}()
+	}
+	// Give every caller time to join the load before it finishes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Errorf("load ran %d times, want 1", got)
+	}
+	for i, got := range results {
+		if got != 42 {
+			t.Errorf("caller %d got %d, want 42", i, got)
+		}
+	}
+}
+
+func TestRefreshingCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxAge    time.Duration
+		run       func(cache *refreshingCache[int]) (int, error)
+		want      int
+		wantLoads int32
+		wantErr   bool
+	}{
+		{
+			name:   "fresh snapshot is reused",
+			maxAge: time.Hour,
+			run: func(cache *refreshingCache[int]) (int, error) {
+				cache.get()
+				return cache.get()
+			},
+			want:      1,
+			wantLoads: 1,
+		},
+		{
+			name:   "stale snapshot is reloaded",
+			maxAge: time.Nanosecond,
+			run: func(cache *refreshingCache[int]) (int, error) {
+				cache.get()
+				time.Sleep(time.Millisecond)
+				return cache.get()
+			},
+			want:      2,
+			wantLoads: 2,
+		},
+		{
+			name:   "update applies without a reload",
+			maxAge: time.Hour,
+			run: func(cache *refreshingCache[int]) (int, error) {
+				cache.get()
+				cache.update(func(value int) int { return value + 100 })
+				return cache.get()
+			},
+			want:      101,
+			wantLoads: 1,
+		},
+		{
+			name:   "invalidate forces a reload",
+			maxAge: time.Hour,
+			run: func(cache *refreshingCache[int]) (int, error) {
+				cache.get()
+				cache.invalidate()
+				return cache.get()
+			},
+			want:      2,
+			wantLoads: 2,
+		},
+		{
+			name:   "load errors are returned",
+			maxAge: time.Hour,
+			run: func(cache *refreshingCache[int]) (int, error) {
+				cache.get()
+				cache.invalidate()
+				cache.get()
+				cache.invalidate()
+				return cache.get()
+			},
+			wantLoads: 3,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var loads atomic.Int32
+			cache := newRefreshingCache(tt.maxAge, func() (int, error) {
+				n := loads.Add(1)
+				if n == 3 {
+					return 0, errors.New("database unavailable")
+				}
+				return int(n), nil
+			})
+
+			got, err := tt.run(cache)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("get() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("get() = %d, want %d", got, tt.want)
+			}
+			if loads.Load() != tt.wantLoads {
+				t.Errorf("load ran %d times, want %d", loads.Load(), tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestRefreshingCacheDiscardsLoadsThatMissedAnUpdate(t *testing.T) {
+	var loads atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	cache := newRefreshingCache(time.Hour, func() (int, error) {
+		n := loads.Add(1)
+		if n == 2 {
+			close(started)
+			<-release
+		}
+		return int(n), nil
+	})
+	cache.get()
+	cache.invalidate()
+
+	done := make(chan int)
+	go func() {
+		value, _ := cache.get()
+		done <- value
+	}()
+	<-started
+	// The update lands while the reload is running, so the reload may have missed it.
+	cache.update(func(value int) int { return value + 100 })
+	close(release)
+
+	// The cache had no current snapshot after the invalidation, so the load is retried.
+	if got := <-done; got != 3 {
+		t.Errorf("get() = %d, want 3 from the retried load", got)
+	}
+	if got := loads.Load(); got != 3 {
+		t.Errorf("load ran %d times, want 3", got)
+	}
+}
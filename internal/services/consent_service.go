@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// consentRefreshInterval is how stale the cached current documents and acceptances may get,
+// i.e. how long after a new version takes effect callers may keep working without accepting it.
+const consentRefreshInterval = time.Minute
+
+// defaultConsentSource is recorded when a request does not say where the decision was made.
+const defaultConsentSource = "api"
+
+// documentKinds maps protobuf document kinds to their stored names.
+var documentKinds = map[pb.LegalDocumentKind]string{
+	pb.LegalDocumentKind_LEGAL_DOCUMENT_KIND_TERMS:   models.DocumentTerms,
+	pb.LegalDocumentKind_LEGAL_DOCUMENT_KIND_PRIVACY: models.DocumentPrivacy,
+}
+
+// ConsentService records which legal document versions users accepted and their marketing
+// preferences, and tells the consent interceptor who still has to accept the current terms.
+type ConsentService struct {
+	Repo     *repositories.ConsentRepository
+	UserRepo *repositories.UserRepository
+	Audit    *AuditService
+
+	legal *refreshingCache[*legalSnapshot]
+}
+
+// legalSnapshot is the set of documents in effect, with the subjects known to have accepted them.
+type legalSnapshot struct {
+	documents map[string]*models.LegalDocument
+
+	mu       sync.Mutex
+	accepted map[string]time.Time // Subjects known to have accepted documents, and when that was checked
+}
+
+// NewConsentService creates a new ConsentService instance.
+func NewConsentService(repo *repositories.ConsentRepository, userRepo *repositories.UserRepository, audit *AuditService) *ConsentService {
+	s := &ConsentService{
+		Repo:     repo,
+		UserRepo: userRepo,
+		Audit:    audit,
+	}
+	s.legal = newRefreshingCache(consentRefreshInterval, s.loadLegalSnapshot)
+	return s
+}
+
+// ✅ PublishLegalDocument
+func (s *ConsentService) PublishLegalDocument(ctx context.Context, req *pb.PublishLegalDocumentRequest) (*pb.LegalDocument, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	kind, ok := documentKinds[req.Kind]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "kind is required")
+	}
+	version := strings.TrimSpace(req.Version)
+	if version == "" || len(version) > 50 {
+		return nil, status.Error(codes.InvalidArgument, "version must be between 1 and 50 characters")
+	}
+	if parsed, err := url.Parse(req.Url); err != nil || parsed.Host == "" || len(req.Url) > 2048 {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute URL")
+	}
+
+	effectiveAt := time.Now()
+	if requested, err := requestTimestamp("effective_at", req.EffectiveAt); err != nil {
+		return nil, err
+	} else if requested != nil {
+		effectiveAt = *requested
+	}
+
+	log.Printf("🔹 Publishing legal document | Kind: %s | Version: %s", kind, version)
+
+	doc := &models.LegalDocument{
+		Kind:        kind,
+		Version:     version,
+		URL:         req.Url,
+		EffectiveAt: effectiveAt.UTC(),
+		CreatedBy:   stringPtr(callerSubject(ctx)),
+	}
+	if err := s.Repo.PublishDocument(doc); err != nil {
+		log.Printf("❌ Failed to publish legal document: %v", err)
+		if errors.Is(err, repositories.ErrDocumentExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, err
+	}
+	if err := s.Audit.Record(ctx, models.AuditDocumentPublished, "", map[string]string{"kind": kind, "version": version, "url": doc.URL}); err != nil {
+		return nil, err
+	}
+
+	// Pick the new version up on the next check, and drop any reload already in flight.
+	s.legal.invalidate()
+
+	log.Printf("✅ Legal document published | Kind: %s | Version: %s", kind, version)
+	return toLegalDocumentResponse(doc), nil
+}
+
+// ✅ AcceptTerms - Only users themselves can accept; not admins on their behalf, nor staff impersonating them
+func (s *ConsentService) AcceptTerms(ctx context.Context, req *pb.AcceptTermsRequest) (*pb.Consents, error) {
+	claims := auth.FromContext(ctx)
+	if claims == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if claims.IsImpersonated() {
+		return nil, status.Error(codes.PermissionDenied, "terms can only be accepted by the user themselves")
+	}
+	auth0ID := claims.Subject
+	if req.TermsVersion == "" && req.PrivacyVersion == "" {
+		return nil, status.Error(codes.InvalidArgument, "terms_version or privacy_version is required")
+	}
+	source, err := consentSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Accepting terms | Auth0ID: %s | Terms: %s | Privacy: %s", auth0ID, req.TermsVersion, req.PrivacyVersion)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	var decisions []*models.ConsentDecision
+	details := make(map[string]string)
+	for kind, version := range map[string]string{models.DocumentTerms: req.TermsVersion, models.DocumentPrivacy: req.PrivacyVersion} {
+		if version == "" {
+			continue
+		}
+		if _, err := s.Repo.GetDocument(kind, version); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Errorf(codes.NotFound, "%s version %q not found", kind, version)
+			}
+			return nil, err
+		}
+		decisions = append(decisions, newConsentDecision(ctx, user.ID, kind, &version, true, source))
+		details[kind] = version
+	}
+
+	if err := s.Repo.RecordDecisions(decisions); err != nil {
+		log.Printf("❌ Failed to record terms acceptance: %v", err)
+		return nil, err
+	}
+	if err := s.Audit.Record(ctx, models.AuditConsentUpdated, auth0ID, details); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Terms accepted | Auth0ID: %s", auth0ID)
+	return s.consents(user)
+}
+
+// ✅ GetConsents
+func (s *ConsentService) GetConsents(ctx context.Context, req *pb.GetConsentsRequest) (*pb.Consents, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Retrieving consents | Auth0ID: %s", auth0ID)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	resp, err := s.consents(user)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve consents: %v", err)
+		return nil, err
+	}
+
+	log.Printf("✅ Consents retrieved | Auth0ID: %s | UpToDate: %t", auth0ID, resp.UpToDate)
+	return resp, nil
+}
+
+// ✅ UpdateMarketingPreferences
+func (s *ConsentService) UpdateMarketingPreferences(ctx context.Context, req *pb.UpdateMarketingPreferencesRequest) (*pb.Consents, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+	source, err := consentSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Updating marketing preferences | Auth0ID: %s", auth0ID)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	var decisions []*models.ConsentDecision
+	details := make(map[string]string)
+	for kind, granted := range map[string]*bool{models.ConsentMarketingEmail: req.Email, models.ConsentMarketingPush: req.Push} {
+		if granted == nil {
+			continue
+		}
+		decisions = append(decisions, newConsentDecision(ctx, user.ID, kind, nil, *granted, source))
+		details[kind] = strconv.FormatBool(*granted)
+	}
+
+	if len(decisions) > 0 {
+		if err := s.Repo.RecordDecisions(decisions); err != nil {
+			log.Printf("❌ Failed to record marketing preferences: %v", err)
+			return nil, err
+		}
+		if err := s.Audit.Record(ctx, models.AuditConsentUpdated, auth0ID, details); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Printf("✅ Marketing preferences updated | Auth0ID: %s | Changes: %d", auth0ID, len(decisions))
+	return s.consents(user)
+}
+
+// HasAcceptedCurrentTerms implements interceptors.ConsentChecker. Callers without an active account
+// (e.g. machine-to-machine clients, or users before CreateUser) have nothing to accept.
+func (s *ConsentService) HasAcceptedCurrentTerms(ctx context.Context, subject string) (bool, error) {
+	legal, err := s.legal.get()
+	if err != nil {
+		return false, err
+	}
+	if len(legal.documents) == 0 {
+		return true, nil
+	}
+
+	legal.mu.Lock()
+	checkedAt, cached := legal.accepted[subject]
+	legal.mu.Unlock()
+	if cached && time.Since(checkedAt) < consentRefreshInterval {
+		return true, nil
+	}
+
+	versions, err := s.Repo.AcceptedVersions(subject)
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !acceptedAll(legal.documents, versions) {
+		return false, nil
+	}
+
+	// Acceptances are cached with the documents they were checked against, and are forgotten
+	// when those are reloaded.
+	legal.mu.Lock()
+	legal.accepted[subject] = time.Now()
+	legal.mu.Unlock()
+	return true, nil
+}
+
+// loadLegalSnapshot loads the documents in effect, with no acceptances known yet.
+func (s *ConsentService) loadLegalSnapshot() (*legalSnapshot, error) {
+	documents, err := s.Repo.CurrentDocuments()
+	if err != nil {
+		return nil, err
+	}
+	return &legalSnapshot{documents: documents, accepted: make(map[string]time.Time)}, nil
+}
+
+// consents builds a user's consent state.
+func (s *ConsentService) consents(user *models.User) (*pb.Consents, error) {
+	current, err := s.Repo.CurrentDocuments()
+	if err != nil {
+		return nil, err
+	}
+	decisions, err := s.Repo.LatestDecisions(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string)
+	for kind, decision := range decisions {
+		if decision.Version != nil {
+			versions[kind] = *decision.Version
+		}
+	}
+
+	return &pb.Consents{
+		Auth0Id:        user.Auth0ID,
+		Terms:          toConsentRecordResponse(decisions[models.DocumentTerms]),
+		Privacy:        toConsentRecordResponse(decisions[models.DocumentPrivacy]),
+		CurrentTerms:   toLegalDocumentResponse(current[models.DocumentTerms]),
+		CurrentPrivacy: toLegalDocumentResponse(current[models.DocumentPrivacy]),
+		UpToDate:       acceptedAll(current, versions),
+		MarketingEmail: toConsentRecordResponse(decisions[models.ConsentMarketingEmail]),
+		MarketingPush:  toConsentRecordResponse(decisions[models.ConsentMarketingPush]),
+	}, nil
+}
+
+// acceptedAll reports whether the accepted versions cover every current document.
+func acceptedAll(current map[string]*models.LegalDocument, accepted map[string]string) bool {
+	for kind, doc := range current {
+		if accepted[kind] != doc.Version {
+			return false
+		}
+	}
+	return true
+}
+
+// consentSource validates the source of a decision, defaulting when unset.
+func consentSource(source string) (string, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return defaultConsentSource, nil
+	}
+	if len(source) > 50 {
+		return "", status.Error(codes.InvalidArgument, "source must be at most 50 characters")
+	}
+	return source, nil
+}
+
+// newConsentDecision describes a decision made by the caller.
+func newConsentDecision(ctx context.Context, userID, kind string, version *string, granted bool, source string) *models.ConsentDecision {
+	return &models.ConsentDecision{
+		UserID:    userID,
+		Kind:      kind,
+		Version:   version,
+		Granted:   granted,
+		Source:    source,
+		IPAddress: stringPtr(utils.ClientIP(ctx)),
+		UserAgent: stringPtr(truncate(utils.UserAgent(ctx), 512)),
+	}
+}
+
+// toLegalDocumentResponse converts a document into its protobuf representation (nil for nil).
+func toLegalDocumentResponse(doc *models.LegalDocument) *pb.LegalDocument {
+	if doc == nil {
+		return nil
+	}
+	kind := pb.LegalDocumentKind_LEGAL_DOCUMENT_KIND_UNSPECIFIED
+	for value, name := range documentKinds {
+		if name == doc.Kind {
+			kind = value
+		}
+	}
+	return &pb.LegalDocument{
+		Kind:        kind,
+		Version:     doc.Version,
+		Url:         doc.URL,
+		EffectiveAt: utils.ToProtoTimestamp(doc.EffectiveAt),
+	}
+}
+
+// toConsentRecordResponse converts a decision into its protobuf representation (nil for nil).
+func toConsentRecordResponse(decision *models.ConsentDecision) *pb.ConsentRecord {
+	if decision == nil {
+		return nil
+	}
+	return &pb.ConsentRecord{
+		Version:   derefString(decision.Version),
+		Granted:   decision.Granted,
+		Source:    decision.Source,
+		DecidedAt: utils.ToProtoTimestamp(decision.DecidedAt),
+	}
+}
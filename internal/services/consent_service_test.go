@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestConsentRejections(t *testing.T) {
+	s := &ConsentService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+	jane := callerContext("auth0|jane")
+	terms := pb.LegalDocumentKind_LEGAL_DOCUMENT_KIND_TERMS
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{"publish without admin", func() error {
+			_, err := s.PublishLegalDocument(jane, &pb.PublishLegalDocumentRequest{Kind: terms, Version: "v1", Url: "https://example.com/terms"})
+			return err
+		}, codes.PermissionDenied},
+		{"publish without a kind", func() error {
+			_, err := s.PublishLegalDocument(admin, &pb.PublishLegalDocumentRequest{Version: "v1", Url: "https://example.com/terms"})
+			return err
+		}, codes.InvalidArgument},
+		{"publish with a blank version", func() error {
+			_, err := s.PublishLegalDocument(admin, &pb.PublishLegalDocumentRequest{Kind: terms, Version: " ", Url: "https://example.com/terms"})
+			return err
+		}, codes.InvalidArgument},
+		{"publish with a relative url", func() error {
+			_, err := s.PublishLegalDocument(admin, &pb.PublishLegalDocumentRequest{Kind: terms, Version: "v1", Url: "/terms"})
+			return err
+		}, codes.InvalidArgument},
+		{"accept unauthenticated", func() error {
+			_, err := s.AcceptTerms(context.Background(), &pb.AcceptTermsRequest{TermsVersion: "v1"})
+			return err
+		}, codes.Unauthenticated},
+		{"accept while impersonated", func() error {
+			impersonated := auth.NewContext(context.Background(), &auth.Claims{Subject: "auth0|jane", Impersonator: "auth0|staff"})
+			_, err := s.AcceptTerms(impersonated, &pb.AcceptTermsRequest{TermsVersion: "v1"})
+			return err
+		}, codes.PermissionDenied},
+		{"accept without a version", func() error {
+			_, err := s.AcceptTerms(jane, &pb.AcceptTermsRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"get another user's consents", func() error {
+			_, err := s.GetConsents(jane, &pb.GetConsentsRequest{Auth0Id: "auth0|john"})
+			return err
+		}, codes.PermissionDenied},
+		{"update another user's preferences", func() error {
+			_, err := s.UpdateMarketingPreferences(jane, &pb.UpdateMarketingPreferencesRequest{Auth0Id: "auth0|john"})
+			return err
+		}, codes.PermissionDenied},
+		{"update with a long source", func() error {
+			_, err := s.UpdateMarketingPreferences(jane, &pb.UpdateMarketingPreferencesRequest{Source: strings.Repeat("a", 51)})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAcceptedAll(t *testing.T) {
+	current := map[string]*models.LegalDocument{
+		models.DocumentTerms:   {Kind: models.DocumentTerms, Version: "2026-03"},
+		models.DocumentPrivacy: {Kind: models.DocumentPrivacy, Version: "v4"},
+	}
+
+	tests := []struct {
+		name     string
+		current  map[string]*models.LegalDocument
+		accepted map[string]string
+		want     bool
+	}{
+		{"nothing published", nil, nil, true},
+		{"nothing accepted", current, nil, false},
+		{"all current", current, map[string]string{models.DocumentTerms: "2026-03", models.DocumentPrivacy: "v4"}, true},
+		{"outdated terms", current, map[string]string{models.DocumentTerms: "2025-11", models.DocumentPrivacy: "v4"}, false},
+		{"privacy missing", current, map[string]string{models.DocumentTerms: "2026-03"}, false},
+		{"only terms published", map[string]*models.LegalDocument{models.DocumentTerms: current[models.DocumentTerms]}, map[string]string{models.DocumentTerms: "2026-03"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := acceptedAll(tt.current, tt.accepted); got != tt.want {
+				t.Errorf("acceptedAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsentSource(t *testing.T) {
+	tests := []struct {
+		source   string
+		want     string
+		wantCode codes.Code
+	}{
+		{"", defaultConsentSource, codes.OK},
+		{"   ", defaultConsentSource, codes.OK},
+		{" ios_signup ", "ios_signup", codes.OK},
+		{strings.Repeat("a", 50), strings.Repeat("a", 50), codes.OK},
+		{strings.Repeat("a", 51), "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		got, err := consentSource(tt.source)
+		if status.Code(err) != tt.wantCode || got != tt.want {
+			t.Errorf("consentSource(%q) = %q, %v, want %q, %v", tt.source, got, err, tt.want, tt.wantCode)
+		}
+	}
+}
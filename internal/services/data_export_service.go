@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+const (
+	// dataExportBatchSize is how many jobs a worker claims per run.
+	dataExportBatchSize = 5
+	// dataExportStaleAfter is how long a job may stay running before another worker retries it.
+	dataExportStaleAfter = 15 * time.Minute
+)
+
+var errDataExportNotFound = status.Error(codes.NotFound, "data export not found")
+
+// exportStatuses maps stored job states to their protobuf representation.
+var exportStatuses = map[string]pb.DataExportStatus{
+	models.DataExportPending:   pb.DataExportStatus_DATA_EXPORT_STATUS_PENDING,
+	models.DataExportRunning:   pb.DataExportStatus_DATA_EXPORT_STATUS_RUNNING,
+	models.DataExportSucceeded: pb.DataExportStatus_DATA_EXPORT_STATUS_SUCCEEDED,
+	models.DataExportFailed:    pb.DataExportStatus_DATA_EXPORT_STATUS_FAILED,
+}
+
+// DataExportService answers personal data requests: it queues export jobs, builds their JSON
+// archives in the background and serves them until they expire.
+type DataExportService struct {
+	Repo      *repositories.DataExportRepository
+	UserRepo  *repositories.UserRepository
+	Audit     *AuditService
+	Retention time.Duration // How long jobs and archives are kept
+}
+
+// NewDataExportService creates a new DataExportService instance.
+func NewDataExportService(repo *repositories.DataExportRepository, userRepo *repositories.UserRepository, audit *AuditService, cfg *config.Config) *DataExportService {
+	return &DataExportService{
+		Repo:      repo,
+		UserRepo:  userRepo,
+		Audit:     audit,
+		Retention: cfg.DataExportRetention,
+	}
+}
+
+// ✅ ExportMyData
+func (s *DataExportService) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.DataExport, error) {
+	claims := auth.FromContext(ctx)
+	if claims == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return s.startExport(ctx, claims.Subject)
+}
+
+// ✅ ExportUserData
+func (s *DataExportService) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.DataExport, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateAuth0ID(req.Auth0Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return s.startExport(ctx, req.Auth0Id)
+}
+
+// startExport queues an export of the user's data.
+func (s *DataExportService) startExport(ctx context.Context, auth0ID string) (*pb.DataExport, error) {
+	log.Printf("🔹 Requesting data export | Auth0ID: %s", auth0ID)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	export := &models.DataExport{
+		ID:          uuid.NewString(),
+		UserID:      user.ID,
+		RequestedBy: stringPtr(callerSubject(ctx)),
+		ExpiresAt:   time.Now().Add(s.Retention).UTC(),
+	}
+	if err := s.Repo.CreateExport(export); err != nil {
+		log.Printf("❌ Failed to queue data export: %v", err)
+		return nil, err
+	}
+	if err := s.Audit.Record(ctx, models.AuditDataExportRequested, auth0ID, map[string]string{"export_id": export.ID}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Data export queued: %s", export.ID)
+	return toDataExportResponse(export), nil
+}
+
+// ✅ GetDataExport
+func (s *DataExportService) GetDataExport(ctx context.Context, req *pb.GetDataExportRequest) (*pb.DataExport, error) {
+	claims := auth.FromContext(ctx)
+	if claims == nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	if uuid.Validate(req.Id) != nil {
+		return nil, status.Error(codes.InvalidArgument, "id must be a valid UUID")
+	}
+
+	log.Printf("🔹 Retrieving data export: %s", req.Id)
+
+	export, err := s.Repo.GetExport(req.Id)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve data export: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errDataExportNotFound
+		}
+		return nil, err
+	}
+
+	// Other users' exports are reported as missing rather than forbidden.
+	if !claims.HasPermission(auth.PermissionAdmin) {
+		user, err := s.UserRepo.GetUser(claims.Subject)
+		if err != nil || user.ID != export.UserID {
+			return nil, errDataExportNotFound
+		}
+	}
+
+	resp := toDataExportResponse(export)
+	if export.Status == models.DataExportSucceeded {
+		if resp.Archive, err = s.Repo.GetArchive(export.ID); err != nil {
+			log.Printf("❌ Failed to retrieve data export archive: %v", err)
+			return nil, err
+		}
+	}
+
+	log.Printf("✅ Data export retrieved | ID: %s | Status: %s", export.ID, export.Status)
+	return resp, nil
+}
+
+// RunWorker builds the archives of pending exports every interval until ctx is cancelled.
+func (s *DataExportService) RunWorker(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		exports, err := s.Repo.ClaimPendingExports(dataExportBatchSize, time.Now().Add(-dataExportStaleAfter).UTC())
+		if err != nil {
+			log.Printf("❌ Failed to claim data exports: %v", err)
+			return
+		}
+		for _, export := range exports {
+			s.runExport(ctx, export)
+		}
+	})
+}
+
+// runExport builds and stores one archive, recording a failure on the job instead of retrying.
+func (s *DataExportService) runExport(ctx context.Context, export *models.DataExport) {
+	log.Printf("🔹 Building data export: %s", export.ID)
+
+	archive, err := s.Repo.CollectUserData(ctx, export.UserID)
+	var data []byte
+	if err == nil {
+		archive.Format = models.DataExportFormat
+		archive.Version = models.DataExportVersion
+		archive.ExportID = export.ID
+		data, err = json.MarshalIndent(archive, "", "  ")
+	}
+	if err != nil {
+		log.Printf("❌ Data export %s failed: %v", export.ID, err)
+		if err := s.Repo.FailExport(export.ID, "failed to collect user data"); err != nil {
+			log.Printf("❌ Failed to record data export failure: %v", err)
+		}
+		return
+	}
+
+	if err := s.Repo.CompleteExport(export.ID, data); err != nil {
+		log.Printf("❌ Failed to store data export %s: %v", export.ID, err)
+		return
+	}
+	log.Printf("✅ Data export ready | ID: %s | Bytes: %d", export.ID, len(data))
+}
+
+// RunGarbageCollector deletes expired exports and their archives every interval until ctx is cancelled.
+func (s *DataExportService) RunGarbageCollector(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		deleted, err := s.Repo.DeleteExpired()
+		if err != nil {
+			log.Printf("❌ Failed to garbage-collect data exports: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("✅ Garbage-collected %d expired data exports", deleted)
+		}
+	})
+}
+
+// toDataExportResponse converts a job into its protobuf representation, without the archive.
+func toDataExportResponse(export *models.DataExport) *pb.DataExport {
+	return &pb.DataExport{
+		Id:          export.ID,
+		UserId:      export.UserID,
+		Status:      exportStatuses[export.Status],
+		Error:       derefString(export.Error),
+		CreatedAt:   utils.ToProtoTimestamp(export.CreatedAt),
+		CompletedAt: utils.ToOptionalProtoTimestamp(export.CompletedAt),
+		ExpiresAt:   utils.ToProtoTimestamp(export.ExpiresAt),
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestDataExportRejections(t *testing.T) {
+	s := &DataExportService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{"export my data unauthenticated", func() error {
+			_, err := s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{})
+			return err
+		}, codes.Unauthenticated},
+		{"export user data unauthenticated", func() error {
+			_, err := s.ExportUserData(context.Background(), &pb.ExportUserDataRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.Unauthenticated},
+		{"export user data without admin", func() error {
+			_, err := s.ExportUserData(callerContext("auth0|jane", auth.PermissionReadUserEmails), &pb.ExportUserDataRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.PermissionDenied},
+		{"export user data without auth0_id", func() error {
+			_, err := s.ExportUserData(admin, &pb.ExportUserDataRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"get export unauthenticated", func() error {
+			_, err := s.GetDataExport(context.Background(), &pb.GetDataExportRequest{Id: "9b2f4c1e-5d3a-4e8b-a7c6-0f1e2d3c4b5a"})
+			return err
+		}, codes.Unauthenticated},
+		{"get export with an invalid id", func() error {
+			_, err := s.GetDataExport(callerContext("auth0|jane"), &pb.GetDataExportRequest{Id: "export-1"})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestToDataExportResponse(t *testing.T) {
+	completedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	export := &models.DataExport{
+		ID:          "9b2f4c1e-5d3a-4e8b-a7c6-0f1e2d3c4b5a",
+		UserID:      "4f3e2d1c-0b9a-4876-b543-210fedcba987",
+		Status:      models.DataExportSucceeded,
+		Archive:     []byte(`{"format":"bandroom.user-data-export"}`),
+		CompletedAt: &completedAt,
+		ExpiresAt:   completedAt.Add(7 * 24 * time.Hour),
+	}
+
+	resp := toDataExportResponse(export)
+	if resp.Status != pb.DataExportStatus_DATA_EXPORT_STATUS_SUCCEEDED || !resp.CompletedAt.AsTime().Equal(completedAt) {
+		t.Errorf("toDataExportResponse() = %v, want a succeeded job completed at %v", resp, completedAt)
+	}
+	if resp.Archive != nil {
+		t.Error("toDataExportResponse() included the archive")
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"log"
+	"maps"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// DenylistService cuts off access tokens before their natural expiry, either one at a time by "jti"
+// or per user for everything issued up to a cutoff.
+type DenylistService struct {
+	Repo          *repositories.DenylistRepository
+	Audit         *AuditService
+	TokenLifetime time.Duration // Longest access token lifetime; bounds how long entries are kept
+
+	entries *refreshingCache[denylistEntries]
+}
+
+// denylistEntries is a snapshot of the denylist.
+type denylistEntries struct {
+	tokens  map[string]struct{}  // Denied token IDs
+	cutoffs map[string]time.Time // Per-subject cutoffs: tokens issued at or before them are denied
+}
+
+// NewDenylistService creates a new DenylistService instance.
+func NewDenylistService(repo *repositories.DenylistRepository, audit *AuditService, cfg *config.Config) *DenylistService {
+	s := &DenylistService{
+		Repo:          repo,
+		Audit:         audit,
+		TokenLifetime: cfg.TokenMaxLifetime,
+	}
+	s.entries = newRefreshingCache(revocationRefreshInterval, s.loadEntries)
+	return s
+}
+
+// ✅ RevokeToken
+func (s *DenylistService) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	jti := strings.TrimSpace(req.Jti)
+	if jti == "" {
+		return nil, status.Error(codes.InvalidArgument, "jti is required")
+	}
+
+	expiresAt := time.Now().Add(s.TokenLifetime)
+	if requested, err := requestTimestamp("expires_at", req.ExpiresAt); err != nil {
+		return nil, err
+	} else if requested != nil {
+		if !requested.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		expiresAt = *requested
+	}
+
+	log.Printf("🔹 Revoking token | JTI: %s", jti)
+
+	token := &models.RevokedToken{
+		JTI:       truncate(jti, 255),
+		Subject:   stringPtr(truncate(req.Auth0Id, 255)),
+		ExpiresAt: expiresAt.UTC(),
+		Reason:    stringPtr(truncate(req.Reason, 255)),
+		RevokedBy: stringPtr(callerSubject(ctx)),
+	}
+	if err := s.Repo.RevokeToken(token); err != nil {
+		log.Printf("❌ Failed to revoke token: %v", err)
+		return nil, err
+	}
+
+	s.entries.update(func(entries denylistEntries) denylistEntries {
+		entries.tokens = maps.Clone(entries.tokens)
+		if entries.tokens == nil {
+			entries.tokens = make(map[string]struct{})
+		}
+		entries.tokens[token.JTI] = struct{}{}
+		return entries
+	})
+	if err := s.Audit.Record(ctx, models.AuditTokenRevoked, req.Auth0Id, map[string]string{"jti": token.JTI, "reason": req.Reason}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Token revoked | JTI: %s", jti)
+	return &pb.RevokeTokenResponse{
+		Jti:       token.JTI,
+		ExpiresAt: utils.ToProtoTimestamp(token.ExpiresAt),
+		RevokedAt: utils.ToProtoTimestamp(token.RevokedAt),
+	}, nil
+}
+
+// ✅ RevokeUserTokens
+func (s *DenylistService) RevokeUserTokens(ctx context.Context, req *pb.RevokeUserTokensRequest) (*pb.RevokeUserTokensResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateAuth0ID(req.Auth0Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	revokedBefore := time.Now()
+	if requested, err := requestTimestamp("revoked_before", req.RevokedBefore); err != nil {
+		return nil, err
+	} else if requested != nil {
+		if requested.After(revokedBefore) {
+			return nil, status.Error(codes.InvalidArgument, "revoked_before cannot be in the future")
+		}
+		revokedBefore = *requested
+	}
+
+	log.Printf("🔹 Revoking tokens | Auth0ID: %s | IssuedBefore: %s", req.Auth0Id, utils.FormatTimestamp(revokedBefore))
+
+	revocation := &models.UserTokenRevocation{
+		Subject:       req.Auth0Id,
+		RevokedBefore: revokedBefore.UTC(),
+		ExpiresAt:     revokedBefore.Add(s.TokenLifetime).UTC(),
+		Reason:        stringPtr(truncate(req.Reason, 255)),
+		RevokedBy:     stringPtr(callerSubject(ctx)),
+	}
+	if err := s.Repo.RevokeUserTokens(revocation); err != nil {
+		log.Printf("❌ Failed to revoke tokens: %v", err)
+		return nil, err
+	}
+
+	s.entries.update(func(entries denylistEntries) denylistEntries {
+		entries.cutoffs = maps.Clone(entries.cutoffs)
+		if entries.cutoffs == nil {
+			entries.cutoffs = make(map[string]time.Time)
+		}
+		entries.cutoffs[revocation.Subject] = revocation.RevokedBefore
+		return entries
+	})
+	if err := s.Audit.Record(ctx, models.AuditUserTokensRevoked, revocation.Subject, map[string]string{
+		"revoked_before": utils.FormatTimestamp(revocation.RevokedBefore),
+		"reason":         req.Reason,
+	}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Tokens revoked | Auth0ID: %s", req.Auth0Id)
+	return &pb.RevokeUserTokensResponse{
+		Auth0Id:       revocation.Subject,
+		RevokedBefore: utils.ToProtoTimestamp(revocation.RevokedBefore),
+		ExpiresAt:     utils.ToProtoTimestamp(revocation.ExpiresAt),
+		RevokedAt:     utils.ToProtoTimestamp(revocation.RevokedAt),
+	}, nil
+}
+
+// IsRevoked implements interceptors.RevocationChecker: denied token IDs and tokens issued
+// at or before their subject's cutoff are rejected.
+func (s *DenylistService) IsRevoked(ctx context.Context, claims *auth.Claims) (bool, error) {
+	entries, err := s.entries.get()
+	if err != nil {
+		return false, err
+	}
+
+	if claims.ID != "" {
+		if _, revoked := entries.tokens[claims.ID]; revoked {
+			return true, nil
+		}
+	}
+	// "iat" has second precision, so a token issued in the same second as the cutoff is denied too.
+	if cutoff, ok := entries.cutoffs[claims.Subject]; ok && !claims.IssuedAt.After(cutoff) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// loadEntries loads the denylist entries that have not expired.
+func (s *DenylistService) loadEntries() (denylistEntries, error) {
+	tokens, revocations, err := s.Repo.ListActiveEntries()
+	if err != nil {
+		return denylistEntries{}, err
+	}
+	cutoffs := make(map[string]time.Time, len(revocations))
+	for subject, revocation := range revocations {
+		cutoffs[subject] = revocation.RevokedBefore
+	}
+	return denylistEntries{tokens: tokens, cutoffs: cutoffs}, nil
+}
+
+// RunGarbageCollector deletes expired denylist entries every interval until ctx is cancelled.
+func (s *DenylistService) RunGarbageCollector(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		deleted, err := s.Repo.DeleteExpired()
+		if err != nil {
+			log.Printf("❌ Failed to garbage-collect token denylist: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("✅ Garbage-collected %d expired denylist entries", deleted)
+		}
+		// Force a reload so expired entries also leave the cache.
+		s.entries.invalidate()
+	})
+}
+
+// callerSubject returns the Auth0 ID of the authenticated caller, if any.
+func callerSubject(ctx context.Context) string {
+	if claims := auth.FromContext(ctx); claims != nil {
+		return claims.Subject
+	}
+	return ""
+}
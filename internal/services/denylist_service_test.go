@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestRevokeTokenRejectsBeforeRevoking(t *testing.T) {
+	s := &DenylistService{TokenLifetime: time.Hour}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.RevokeTokenRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), &pb.RevokeTokenRequest{Jti: "abc"}, codes.Unauthenticated},
+		{"not an admin", callerContext("auth0|jane"), &pb.RevokeTokenRequest{Jti: "abc"}, codes.PermissionDenied},
+		{"jti missing", admin, &pb.RevokeTokenRequest{Jti: "  "}, codes.InvalidArgument},
+		{"expires_at out of range", admin, &pb.RevokeTokenRequest{Jti: "abc", ExpiresAt: &timestamppb.Timestamp{Nanos: -1}}, codes.InvalidArgument},
+		{"expires_at in the past", admin, &pb.RevokeTokenRequest{Jti: "abc", ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute))}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.RevokeToken(tt.ctx, tt.req); status.Code(err) != tt.wantCode {
+				t.Errorf("RevokeToken() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRevokeUserTokensRejectsBeforeRevoking(t *testing.T) {
+	s := &DenylistService{TokenLifetime: time.Hour}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.RevokeUserTokensRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), &pb.RevokeUserTokensRequest{Auth0Id: "auth0|jane"}, codes.Unauthenticated},
+		{"not an admin", callerContext("auth0|jane"), &pb.RevokeUserTokensRequest{Auth0Id: "auth0|jane"}, codes.PermissionDenied},
+		{"auth0_id missing", admin, &pb.RevokeUserTokensRequest{}, codes.InvalidArgument},
+		{"revoked_before out of range", admin, &pb.RevokeUserTokensRequest{Auth0Id: "auth0|jane", RevokedBefore: &timestamppb.Timestamp{Seconds: 1e12}}, codes.InvalidArgument},
+		{"revoked_before in the future", admin, &pb.RevokeUserTokensRequest{Auth0Id: "auth0|jane", RevokedBefore: timestamppb.New(time.Now().Add(time.Hour))}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.RevokeUserTokens(tt.ctx, tt.req); status.Code(err) != tt.wantCode {
+				t.Errorf("RevokeUserTokens() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDenylistIsRevoked(t *testing.T) {
+	cutoff := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := &DenylistService{}
+	s.entries = newRefreshingCache(time.Hour, func() (denylistEntries, error) {
+		return denylistEntries{
+			tokens:  map[string]struct{}{"denied-jti": {}},
+			cutoffs: map[string]time.Time{"auth0|jane": cutoff},
+		}, nil
+	})
+
+	tests := []struct {
+		name   string
+		claims *auth.Claims
+		want   bool
+	}{
+		{"denied jti", &auth.Claims{Subject: "auth0|john", ID: "denied-jti", IssuedAt: cutoff.Add(time.Hour)}, true},
+		{"other jti", &auth.Claims{Subject: "auth0|john", ID: "other-jti", IssuedAt: cutoff.Add(-time.Hour)}, false},
+		{"issued before the cutoff", &auth.Claims{Subject: "auth0|jane", IssuedAt: cutoff.Add(-time.Hour)}, true},
+		{"issued in the cutoff second", &auth.Claims{Subject: "auth0|jane", IssuedAt: cutoff}, true},
+		{"issued after the cutoff", &auth.Claims{Subject: "auth0|jane", IssuedAt: cutoff.Add(time.Second)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.IsRevoked(context.Background(), tt.claims)
+			if err != nil || got != tt.want {
+				t.Errorf("IsRevoked() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// ✅ EraseUser - Replaces a user's personal data with tombstones and records a certificate
+func (s *UserService) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.ErasureCertificate, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateAuth0ID(req.Auth0Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Printf("🔹 Erasing user | Auth0ID: %s", req.Auth0Id)
+
+	certificate := &models.ErasureCertificate{
+		ID:          uuid.NewString(),
+		RequestedBy: stringPtr(callerSubject(ctx)),
+		Reason:      stringPtr(truncate(req.Reason, 255)),
+	}
+	err := s.Repo.EraseUser(req.Auth0Id, certificate, auditContext(ctx))
+	if err != nil {
+		log.Printf("❌ Failed to erase user: %v", err)
+		if errors.Is(err, repositories.ErrAlreadyErased) {
+			return nil, status.Error(codes.FailedPrecondition, "user already erased")
+		}
+		return nil, toStatusError(err)
+	}
+
+	log.Printf("✅ User erased | UserID: %s | Certificate: %s", certificate.UserID, certificate.ID)
+	return toErasureCertificateResponse(certificate), nil
+}
+
+// ✅ GetErasureCertificate
+func (s *UserService) GetErasureCertificate(ctx context.Context, req *pb.GetErasureCertificateRequest) (*pb.ErasureCertificate, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if uuid.Validate(req.UserId) != nil {
+		return nil, status.Error(codes.InvalidArgument, "user_id must be a valid UUID")
+	}
+
+	log.Printf("🔹 Retrieving erasure certificate | UserID: %s", req.UserId)
+
+	certificate, err := s.Repo.GetErasureCertificate(req.UserId)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve erasure certificate: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "erasure certificate not found")
+		}
+		return nil, err
+	}
+
+	log.Printf("✅ Erasure certificate retrieved: %s", certificate.ID)
+	return toErasureCertificateResponse(certificate), nil
+}
+
+// toErasureCertificateResponse converts a certificate into its protobuf representation.
+func toErasureCertificateResponse(certificate *models.ErasureCertificate) *pb.ErasureCertificate {
+	return &pb.ErasureCertificate{
+		Id:          certificate.ID,
+		UserId:      certificate.UserID,
+		RequestedBy: derefString(certificate.RequestedBy),
+		Reason:      derefString(certificate.Reason),
+		Scrubbed:    certificate.Scrubbed,
+		Remaining:   certificate.Remaining,
+		CompletedAt: utils.ToProtoTimestamp(certificate.CompletedAt),
+	}
+}
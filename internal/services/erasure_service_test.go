@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestErasureRejections(t *testing.T) {
+	s := &UserService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+	self := callerContext("auth0|jane")
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{"erase unauthenticated", func() error {
+			_, err := s.EraseUser(context.Background(), &pb.EraseUserRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.Unauthenticated},
+		{"erase own account without admin", func() error {
+			_, err := s.EraseUser(self, &pb.EraseUserRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.PermissionDenied},
+		{"erase without auth0_id", func() error {
+			_, err := s.EraseUser(admin, &pb.EraseUserRequest{Reason: "ticket 42"})
+			return err
+		}, codes.InvalidArgument},
+		{"certificate without admin", func() error {
+			_, err := s.GetErasureCertificate(self, &pb.GetErasureCertificateRequest{UserId: "4f3e2d1c-0b9a-4876-b543-210fedcba987"})
+			return err
+		}, codes.PermissionDenied},
+		{"certificate with an invalid user_id", func() error {
+			_, err := s.GetErasureCertificate(admin, &pb.GetErasureCertificateRequest{UserId: "auth0|jane"})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
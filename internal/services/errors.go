@@ -0,0 +1,32 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+)
+
+// toStatusError maps repository errors onto gRPC status errors.
+func toStatusError(err error) error {
+	var cooldown *repositories.UsernameCooldownError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, repositories.ErrEmailTaken):
+		return status.Error(codes.AlreadyExists, "email already in use")
+	case errors.Is(err, repositories.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, "username already in use")
+	case errors.Is(err, repositories.ErrAuth0IDTaken):
+		return status.Error(codes.AlreadyExists, "auth0_id already in use")
+	case errors.Is(err, repositories.ErrUserDeleted):
+		return status.Error(codes.FailedPrecondition, "account was deleted and cannot be recreated")
+	case errors.As(err, &cooldown):
+		return status.Error(codes.FailedPrecondition, cooldown.Error())
+	default:
+		return err
+	}
+}
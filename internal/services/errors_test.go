@@ -0,0 +1,38 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", sql.ErrNoRows, codes.NotFound},
+		{"email taken", repositories.ErrEmailTaken, codes.AlreadyExists},
+		{"username taken", repositories.ErrUsernameTaken, codes.AlreadyExists},
+		{"auth0 id taken", repositories.ErrAuth0IDTaken, codes.AlreadyExists},
+		{"account deleted", repositories.ErrUserDeleted, codes.FailedPrecondition},
+		{"username cooldown", &repositories.UsernameCooldownError{NextChange: time.Now()}, codes.FailedPrecondition},
+		{"wrapped", fmt.Errorf("create user: %w", repositories.ErrEmailTaken), codes.AlreadyExists},
+		{"unknown", errors.New("connection reset"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(toStatusError(tt.err)); got != tt.want {
+				t.Errorf("toStatusError(%v) code = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
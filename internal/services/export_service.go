@@ -0,0 +1,61 @@
+package services
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// exportBatchSize is how many rows each FETCH pulls from the export cursor.
+const exportBatchSize = 500
+
+// ✅ ExportUsers - Streams every user to an admin, resumable from a checkpoint token
+func (s *UserService) ExportUsers(req *pb.ExportUsersRequest, stream grpc.ServerStreamingServer[pb.ExportUsersResponse]) error {
+	ctx := stream.Context()
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return err
+	}
+
+	token, err := decodePageToken(req.CheckpointToken)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid checkpoint_token")
+	}
+
+	var after *repositories.UserCursor
+	if token != nil {
+		if uuid.Validate(token.ID) != nil {
+			return status.Error(codes.InvalidArgument, "invalid checkpoint_token")
+		}
+		after = &repositories.UserCursor{CreatedAt: token.Time, ID: token.ID}
+		log.Printf("🔹 Resuming user export | After: %s", token.ID)
+	} else {
+		log.Println("🔹 Starting user export")
+	}
+
+	exported := 0
+	err = s.Repo.ExportUsers(ctx, after, req.IncludeDeleted, exportBatchSize, func(user *models.User) error {
+		exported++
+		return stream.Send(&pb.ExportUsersResponse{
+			User:            toUserResponse(user),
+			CheckpointToken: encodePageToken(pageToken{Time: user.CreatedAt, ID: user.ID}),
+		})
+	})
+	if err != nil {
+		log.Printf("❌ User export stopped after %d users: %v", exported, err)
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return err
+	}
+
+	log.Printf("✅ User export completed | Users: %d", exported)
+	return nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// exportStream is an ExportUsers stream that only carries a context.
+type exportStream struct {
+	grpc.ServerStreamingServer[pb.ExportUsersResponse]
+	ctx context.Context
+}
+
+func (s *exportStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestExportUsersRejectsBeforeExport(t *testing.T) {
+	s := &UserService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		token    string
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), "", codes.Unauthenticated},
+		{"not an admin", callerContext("auth0|jane", auth.PermissionReadUserEmails), "", codes.PermissionDenied},
+		{"malformed checkpoint", admin, "!!!", codes.InvalidArgument},
+		{"checkpoint without a cursor", admin, encodePageToken(pageToken{Offset: 10}), codes.InvalidArgument},
+		{"checkpoint cursor not a user id", admin, encodePageToken(pageToken{ID: "'; DROP TABLE users; --"}), codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.ExportUsers(&pb.ExportUsersRequest{CheckpointToken: tt.token}, &exportStream{ctx: tt.ctx})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("ExportUsers() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
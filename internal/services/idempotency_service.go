@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+)
+
+// idempotencyStaleAfter is how long a request may hold its key in progress before a retry may take it over.
+const idempotencyStaleAfter = time.Minute
+
+// IdempotencyService stores the responses of mutating RPCs by idempotency key.
+// It implements interceptors.IdempotencyStore.
+type IdempotencyService struct {
+	Repo *repositories.IdempotencyRepository
+	TTL  time.Duration // How long a key replays its response
+}
+
+// NewIdempotencyService creates a new IdempotencyService instance.
+func NewIdempotencyService(repo *repositories.IdempotencyRepository, cfg *config.Config) *IdempotencyService {
+	return &IdempotencyService{Repo: repo, TTL: cfg.IdempotencyKeyTTL}
+}
+
+// Claim takes ownership of the key, or returns the record of the request that already used it.
+func (s *IdempotencyService) Claim(ctx context.Context, scope, key, method string, requestHash []byte) (*models.IdempotencyKey, error) {
+	now := time.Now()
+	return s.Repo.ClaimKey(&models.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		Method:      method,
+		RequestHash: requestHash,
+		ExpiresAt:   now.Add(s.TTL).UTC(),
+	}, now.Add(-idempotencyStaleAfter).UTC())
+}
+
+// Complete stores the serialized response of a claimed key.
+func (s *IdempotencyService) Complete(ctx context.Context, scope, key string, response []byte) error {
+	return s.Repo.CompleteKey(scope, key, response)
+}
+
+// Release gives up a claimed key after the request failed, so it can be retried.
+func (s *IdempotencyService) Release(ctx context.Context, scope, key string) error {
+	return s.Repo.ReleaseKey(scope, key)
+}
+
+// RunGarbageCollector deletes expired keys every interval until ctx is cancelled.
+func (s *IdempotencyService) RunGarbageCollector(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		deleted, err := s.Repo.DeleteExpired()
+		if err != nil {
+			log.Printf("❌ Failed to garbage-collect idempotency keys: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("✅ Garbage-collected %d expired idempotency keys", deleted)
+		}
+	})
+}
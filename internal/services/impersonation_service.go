@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// ImpersonationService lets support staff act as a user with short-lived tokens, and resolves
+// those tokens for the auth interceptor.
+type ImpersonationService struct {
+	Repo     *repositories.ImpersonationRepository
+	UserRepo *repositories.UserRepository
+	Audit    *AuditService
+	MaxTTL   time.Duration // Longest (and default) token lifetime
+
+	blocked map[string]bool // Full method names refused while impersonating
+}
+
+// NewImpersonationService creates a new ImpersonationService instance. blocked lists the full
+// method names that cannot be called while impersonating.
+func NewImpersonationService(repo *repositories.ImpersonationRepository, userRepo *repositories.UserRepository, audit *AuditService, cfg *config.Config, blocked ...string) *ImpersonationService {
+	s := &ImpersonationService{
+		Repo:     repo,
+		UserRepo: userRepo,
+		Audit:    audit,
+		MaxTTL:   cfg.ImpersonationTTL,
+		blocked:  make(map[string]bool, len(blocked)),
+	}
+	for _, method := range blocked {
+		s.blocked[method] = true
+	}
+	return s
+}
+
+// ✅ Impersonate
+func (s *ImpersonationService) Impersonate(ctx context.Context, req *pb.ImpersonateRequest) (*pb.ImpersonateResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionImpersonateUsers); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateAuth0ID(req.Auth0Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	actor := callerSubject(ctx)
+	if req.Auth0Id == actor {
+		return nil, status.Error(codes.InvalidArgument, "cannot impersonate yourself")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len(reason) > 500 {
+		return nil, status.Error(codes.InvalidArgument, "reason must be between 1 and 500 characters")
+	}
+	ttl := s.MaxTTL
+	if req.TtlSeconds < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds must not be negative")
+	}
+	if requested := time.Duration(req.TtlSeconds) * time.Second; requested > 0 && requested < ttl {
+		ttl = requested
+	}
+
+	log.Printf("🔹 Starting impersonation | Actor: %s | Auth0ID: %s", actor, req.Auth0Id)
+
+	user, err := s.UserRepo.GetUser(req.Auth0Id)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	token := generateImpersonationToken()
+	tokenHash := sha256.Sum256([]byte(token))
+	grant := &models.Impersonation{
+		ID:           uuid.NewString(),
+		TokenHash:    tokenHash[:],
+		ActorSubject: actor,
+		UserID:       user.ID,
+		Subject:      user.Auth0ID,
+		Reason:       reason,
+		ExpiresAt:    time.Now().Add(ttl).UTC(),
+	}
+	if err := s.Repo.CreateImpersonation(grant); err != nil {
+		log.Printf("❌ Failed to create impersonation: %v", err)
+		return nil, err
+	}
+	if err := s.Audit.Record(ctx, models.AuditImpersonationStarted, user.Auth0ID, map[string]string{
+		"impersonation_id": grant.ID,
+		"reason":           reason,
+		"expires_at":       utils.FormatTimestamp(grant.ExpiresAt),
+	}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Impersonation started | ID: %s | Actor: %s | Auth0ID: %s", grant.ID, actor, user.Auth0ID)
+	return &pb.ImpersonateResponse{
+		ImpersonationId: grant.ID,
+		Token:           token,
+		Auth0Id:         user.Auth0ID,
+		ExpiresAt:       utils.ToProtoTimestamp(grant.ExpiresAt),
+	}, nil
+}
+
+// ResolveImpersonation implements interceptors.ImpersonationResolver. The token must have been issued
+// to the actor, who must still hold the impersonation permission. The returned claims act as the user
+// with none of the actor's permissions, and every call made with them is audited.
+func (s *ImpersonationService) ResolveImpersonation(ctx context.Context, method, token string, actor *auth.Claims) (*auth.Claims, error) {
+	if !actor.HasPermission(auth.PermissionImpersonateUsers) {
+		return nil, status.Errorf(codes.PermissionDenied, "impersonation requires the %s permission", auth.PermissionImpersonateUsers)
+	}
+
+	tokenHash := sha256.Sum256([]byte(token))
+	grant, err := s.Repo.GetActiveImpersonation(tokenHash[:])
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.Unauthenticated, "impersonation token is invalid or expired")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to resolve impersonation: %v", err)
+	}
+	if grant.ActorSubject != actor.Subject {
+		return nil, status.Error(codes.Unauthenticated, "impersonation token was issued to another caller")
+	}
+	if s.blocked[method] {
+		log.Printf("❌ Blocked impersonated call | Method: %s | Actor: %s | Auth0ID: %s", method, actor.Subject, grant.Subject)
+		return nil, status.Error(codes.PermissionDenied, "this call is not allowed while impersonating")
+	}
+
+	claims := &auth.Claims{
+		Subject:         grant.Subject,
+		IssuedAt:        grant.CreatedAt,
+		ExpiresAt:       grant.ExpiresAt,
+		Impersonator:    actor.Subject,
+		ImpersonationID: grant.ID,
+	}
+	log.Printf("🔹 Impersonated call | Method: %s | Actor: %s | Auth0ID: %s", method, actor.Subject, grant.Subject)
+	// An impersonated call that leaves no audit entry must not run.
+	if err := s.Audit.Record(auth.NewContext(ctx, claims), models.AuditImpersonatedCall, grant.Subject, map[string]string{
+		"impersonation_id": grant.ID,
+	}); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// generateImpersonationToken returns a random 256-bit token.
+func generateImpersonationToken() string {
+	token := make([]byte, 32)
+	_, _ = rand.Read(token)
+	return "imp_" + base64.RawURLEncoding.EncodeToString(token)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestImpersonationRejections(t *testing.T) {
+	s := &ImpersonationService{MaxTTL: 15 * time.Minute}
+	staff := callerContext("auth0|staff", auth.PermissionImpersonateUsers)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.ImpersonateRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), &pb.ImpersonateRequest{Auth0Id: "auth0|jane", Reason: "ticket 42"}, codes.Unauthenticated},
+		{"without impersonate:users", callerContext("auth0|support", auth.PermissionReadUserEmails), &pb.ImpersonateRequest{Auth0Id: "auth0|jane", Reason: "ticket 42"}, codes.PermissionDenied},
+		{"without auth0_id", staff, &pb.ImpersonateRequest{Reason: "ticket 42"}, codes.InvalidArgument},
+		{"themselves", staff, &pb.ImpersonateRequest{Auth0Id: "auth0|staff", Reason: "ticket 42"}, codes.InvalidArgument},
+		{"without a reason", staff, &pb.ImpersonateRequest{Auth0Id: "auth0|jane", Reason: " "}, codes.InvalidArgument},
+		{"long reason", staff, &pb.ImpersonateRequest{Auth0Id: "auth0|jane", Reason: strings.Repeat("a", 501)}, codes.InvalidArgument},
+		{"negative ttl", staff, &pb.ImpersonateRequest{Auth0Id: "auth0|jane", Reason: "ticket 42", TtlSeconds: -1}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Impersonate(tt.ctx, tt.req); status.Code(err) != tt.wantCode {
+				t.Errorf("Impersonate() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestResolveImpersonationRequiresPermission(t *testing.T) {
+	s := &ImpersonationService{}
+	actor := &auth.Claims{Subject: "auth0|staff", Permissions: []string{auth.PermissionReadUserEmails}}
+
+	_, err := s.ResolveImpersonation(context.Background(), pb.UserService_GetUser_FullMethodName, "imp_token", actor)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ResolveImpersonation() error = %v, want %v", err, codes.PermissionDenied)
+	}
+}
+
+func TestAuditContextRecordsImpersonator(t *testing.T) {
+	if event := auditContext(callerContext("auth0|jane")); event.ImpersonatorSubject != nil {
+		t.Errorf("auditContext() impersonator = %q, want none", *event.ImpersonatorSubject)
+	}
+
+	impersonated := auth.NewContext(context.Background(), &auth.Claims{Subject: "auth0|jane", Impersonator: "auth0|staff"})
+	event := auditContext(impersonated)
+	if derefString(event.ActorSubject) != "auth0|jane" || derefString(event.ImpersonatorSubject) != "auth0|staff" {
+		t.Errorf("auditContext() actor = %q, impersonator = %q, want the user and the staff member",
+			derefString(event.ActorSubject), derefString(event.ImpersonatorSubject))
+	}
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/importer"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+var (
+	errImportDeletedAccount   = errors.New("account was deleted; import does not restore deleted accounts")
+	errImportUsernameChange   = errors.New("username differs from the existing account's; change it with UpdateUsername")
+	errImportConcurrentChange = errors.New("account was deleted or renamed during the import")
+)
+
+// maxReportedImportErrors caps the per-row errors returned in a report; Failed still counts every row.
+const maxReportedImportErrors = 1000
+
+// ImportOptions controls RunImport.
+type ImportOptions struct {
+	DryRun    bool              // Validate and check conflicts without writing
+	BatchSize int               // Rows per multi-row upsert
+	Audit     models.AuditEvent // Who is importing, recorded on each user.imported audit entry
+}
+
+// importRow is a validated row waiting for its batch to be written.
+type importRow struct {
+	line int
+	user *models.User
+}
+
+// ✅ ImportUsers - Client-streaming import of a CSV or JSONL file
+func (s *UserService) ImportUsers(stream grpc.ClientStreamingServer[pb.ImportUsersRequest, pb.ImportUsersResponse]) error {
+	if err := requirePermission(stream.Context(), auth.PermissionAdmin); err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry import options")
+	}
+
+	var format importer.Format
+	switch options.Format {
+	case pb.ImportFormat_IMPORT_FORMAT_CSV:
+		format = importer.FormatCSV
+	case pb.ImportFormat_IMPORT_FORMAT_JSONL:
+		format = importer.FormatJSONL
+	default:
+		return status.Error(codes.InvalidArgument, "format is required")
+	}
+
+	log.Printf("🔹 Importing users over gRPC | Format: %s | DryRun: %t", format, options.DryRun)
+
+	// Feed the streamed chunks to the parser as a single reader.
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if msg.GetOptions() != nil {
+				pw.CloseWithError(status.Error(codes.InvalidArgument, "options may only be sent in the first message"))
+				return
+			}
+			if _, err := pw.Write(msg.GetChunk()); err != nil {
+				return
+			}
+		}
+	}()
+
+	reader, err := importer.NewReader(pr, format)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	report, err := s.RunImport(reader, ImportOptions{DryRun: options.DryRun, BatchSize: s.ImportBatchSize, Audit: auditContext(stream.Context())})
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = status.Errorf(codes.Internal, "import failed: %v", err)
+		}
+		return err
+	}
+
+	return stream.SendAndClose(report)
+}
+
+// RunImport validates every record from the reader and upserts valid rows in batches.
+// Used by both the ImportUsers RPC and the import-users CLI subcommand.
+func (s *UserService) RunImport(reader *importer.Reader, opts ImportOptions) (*pb.ImportUsersResponse, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = s.ImportBatchSize
+	}
+
+	report := &pb.ImportUsersResponse{DryRun: opts.DryRun}
+	seenAuth0IDs := make(map[string]int)
+	seenEmails := make(map[string]int)
+	seenUsernames := make(map[string]int)
+	var batch []importRow
+
+	flush := func() error {
+		err := s.importBatch(batch, opts, report)
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read import input: %w", err)
+		}
+		report.TotalRows++
+
+		if record.Err != nil {
+			recordImportError(report, record.Line, "", record.Err.Error())
+			continue
+		}
+
+		auth0ID := record.Input.Auth0ID
+		email := utils.NormalizeEmail(record.Input.Email)
+		username := utils.NormalizeUsername(record.Input.Username)
+
+		if err := validateImportRow(s.Policy, auth0ID, email, username); err != nil {
+			recordImportError(report, record.Line, auth0ID, err.Error())
+			continue
+		}
+		if line, dup := seenAuth0IDs[auth0ID]; dup {
+			recordImportError(report, record.Line, auth0ID, fmt.Sprintf("duplicate auth0_id (first seen on line %d)", line))
+			continue
+		}
+		if line, dup := seenEmails[email]; dup {
+			recordImportError(report, record.Line, auth0ID, fmt.Sprintf("duplicate email (first seen on line %d)", line))
+			continue
+		}
+		if line, dup := seenUsernames[utils.UsernameKey(username)]; dup && username != "" {
+			recordImportError(report, record.Line, auth0ID, fmt.Sprintf("duplicate username (first seen on line %d)", line))
+			continue
+		}
+
+		seenAuth0IDs[auth0ID] = record.Line
+		seenEmails[email] = record.Line
+		if username != "" {
+			seenUsernames[utils.UsernameKey(username)] = record.Line
+		}
+
+		batch = append(batch, importRow{line: record.Line, user: &models.User{
+			ID:        uuid.NewString(),
+			Auth0ID:   auth0ID,
+			Email:     email,
+			Username:  stringPtr(username),
+			CreatedAt: time.Now().UTC(),
+		}})
+		if len(batch) >= opts.BatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Import finished | Rows: %d | Created: %d | Updated: %d | Valid: %d | Failed: %d",
+		report.TotalRows, report.Created, report.Updated, report.Valid, report.Failed)
+	return report, nil
+}
+
+// importBatch rejects rows that collide with other accounts, would restore a deleted account or would
+// change a username, then upserts the rest in one statement. Usernames on new accounts go through the
+// same reservation and hold checks as CreateUser; existing usernames only change through UpdateUsername.
+// If the statement hits a constraint anyway (e.g. a concurrent signup) the batch is retried row by row.
+func (s *UserService) importBatch(batch []importRow, opts ImportOptions, report *pb.ImportUsersResponse) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var emails, usernameKeys []string
+	for _, row := range batch {
+		emails = append(emails, row.user.Email)
+		if row.user.Username != nil {
+			usernameKeys = append(usernameKeys, utils.UsernameKey(*row.user.Username))
+		}
+	}
+
+	emailOwners, usernameOwners, err := s.Repo.FindIdentityOwners(emails, usernameKeys)
+	if err != nil {
+		return err
+	}
+	auth0IDs := make([]string, len(batch))
+	for i, row := range batch {
+		auth0IDs[i] = row.user.Auth0ID
+	}
+	targets, err := s.Repo.FindImportTargets(auth0IDs)
+	if err != nil {
+		return err
+	}
+
+	var accepted []importRow
+	for _, row := range batch {
+		if owner, ok := emailOwners[row.user.Email]; ok && owner != row.user.Auth0ID {
+			recordImportError(report, row.line, row.user.Auth0ID, repositories.ErrEmailTaken.Error())
+			continue
+		}
+		if row.user.Username != nil {
+			if owner, ok := usernameOwners[utils.UsernameKey(*row.user.Username)]; ok && owner != row.user.Auth0ID {
+				recordImportError(report, row.line, row.user.Auth0ID, repositories.ErrUsernameTaken.Error())
+				continue
+			}
+		}
+
+		current, exists := targets[row.user.Auth0ID]
+		switch {
+		case exists && current.DeletedAt != nil:
+			recordImportError(report, row.line, row.user.Auth0ID, errImportDeletedAccount.Error())
+			continue
+		case exists && row.user.Username != nil && (current.Username == nil || !strings.EqualFold(*current.Username, *row.user.Username)):
+			recordImportError(report, row.line, row.user.Auth0ID, errImportUsernameChange.Error())
+			continue
+		case !exists && row.user.Username != nil:
+			if err := s.checkUsernameClaim(row.user.Auth0ID, *row.user.Username); err != nil {
+				st, ok := status.FromError(err)
+				if !ok {
+					return err
+				}
+				recordImportError(report, row.line, row.user.Auth0ID, st.Message())
+				continue
+			}
+		}
+		accepted = append(accepted, row)
+	}
+
+	if opts.DryRun {
+		report.Valid += int32(len(accepted))
+		return nil
+	}
+
+	users := make([]*models.User, len(accepted))
+	for i, row := range accepted {
+		users[i] = row.user
+	}
+
+	inserted, err := s.Repo.UpsertUsers(users, opts.Audit)
+	if err == nil {
+		countUpserts(report, accepted, inserted)
+		return nil
+	}
+	if !isIdentityConflict(err) {
+		return err
+	}
+
+	log.Printf("❌ Batch upsert hit a conflict, retrying %d rows individually: %v", len(accepted), err)
+	for _, row := range accepted {
+		inserted, err := s.Repo.UpsertUsers([]*models.User{row.user}, opts.Audit)
+		if err != nil {
+			if !isIdentityConflict(err) {
+				return err
+			}
+			recordImportError(report, row.line, row.user.Auth0ID, err.Error())
+			continue
+		}
+		countUpserts(report, []importRow{row}, inserted)
+	}
+	return nil
+}
+
+// validateImportRow applies the same rules as CreateUser to an already-normalized row.
+func validateImportRow(policy *UsernamePolicy, auth0ID, email, username string) error {
+	if err := utils.ValidateAuth0ID(auth0ID); err != nil {
+		return err
+	}
+	if err := utils.ValidateEmail(email); err != nil {
+		return err
+	}
+	if username != "" {
+		return policy.Validate(username)
+	}
+	return nil
+}
+
+// isIdentityConflict reports whether err is a uniqueness violation on a user identity column.
+func isIdentityConflict(err error) bool {
+	return errors.Is(err, repositories.ErrEmailTaken) ||
+		errors.Is(err, repositories.ErrUsernameTaken) ||
+		errors.Is(err, repositories.ErrAuth0IDTaken)
+}
+
+// countUpserts tallies the written rows. Rows the upsert left out were deleted or renamed after
+// importBatch checked them, and are reported as errors.
+func countUpserts(report *pb.ImportUsersResponse, rows []importRow, inserted map[string]bool) {
+	for _, row := range rows {
+		isNew, written := inserted[row.user.Auth0ID]
+		switch {
+		case !written:
+			recordImportError(report, row.line, row.user.Auth0ID, errImportConcurrentChange.Error())
+		case isNew:
+			report.Created++
+		default:
+			report.Updated++
+		}
+	}
+}
+
+func recordImportError(report *pb.ImportUsersResponse, line int, auth0ID, message string) {
+	report.Failed++
+	if len(report.Errors) < maxReportedImportErrors {
+		report.Errors = append(report.Errors, &pb.ImportRowError{Line: int32(line), Auth0Id: auth0ID, Error: message})
+	}
+}
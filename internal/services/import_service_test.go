@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/importer"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// importStream is an ImportUsers stream that replays a fixed list of messages.
+type importStream struct {
+	grpc.ClientStreamingServer[pb.ImportUsersRequest, pb.ImportUsersResponse]
+	ctx      context.Context
+	messages []*pb.ImportUsersRequest
+}
+
+func (s *importStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *importStream) Recv() (*pb.ImportUsersRequest, error) {
+	if len(s.messages) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+func TestImportUsersRejectsBeforeImport(t *testing.T) {
+	s := &UserService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+	chunk := &pb.ImportUsersRequest{Payload: &pb.ImportUsersRequest_Chunk{Chunk: []byte("auth0_id,email\n")}}
+	options := func(format pb.ImportFormat) *pb.ImportUsersRequest {
+		return &pb.ImportUsersRequest{Payload: &pb.ImportUsersRequest_Options{Options: &pb.ImportUsersOptions{Format: format}}}
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		messages []*pb.ImportUsersRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), nil, codes.Unauthenticated},
+		{"not an admin", callerContext("auth0|jane", auth.PermissionReadUserEmails), nil, codes.PermissionDenied},
+		{"chunk before options", admin, []*pb.ImportUsersRequest{chunk}, codes.InvalidArgument},
+		{"format missing", admin, []*pb.ImportUsersRequest{options(pb.ImportFormat_IMPORT_FORMAT_UNSPECIFIED)}, codes.InvalidArgument},
+		{"csv without a header", admin, []*pb.ImportUsersRequest{options(pb.ImportFormat_IMPORT_FORMAT_CSV)}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.ImportUsers(&importStream{ctx: tt.ctx, messages: tt.messages})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("ImportUsers() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateImportRow(t *testing.T) {
+	policy := NewUsernamePolicy([]string{"admin"}, nil)
+
+	tests := []struct {
+		name     string
+		auth0ID  string
+		email    string
+		username string
+		wantErr  bool
+	}{
+		{name: "valid", auth0ID: "auth0|jane", email: "jane@example.com", username: "jane_doe"},
+		{name: "without a username", auth0ID: "auth0|jane", email: "jane@example.com"},
+		{name: "missing auth0_id", email: "jane@example.com", wantErr: true},
+		{name: "invalid email", auth0ID: "auth0|jane", email: "jane", wantErr: true},
+		{name: "invalid username", auth0ID: "auth0|jane", email: "jane@example.com", username: "j", wantErr: true},
+		{name: "reserved username", auth0ID: "auth0|jane", email: "jane@example.com", username: "admin", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateImportRow(policy, tt.auth0ID, tt.email, tt.username)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateImportRow() = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunImportReportsInvalidRows(t *testing.T) {
+	s := &UserService{Policy: NewUsernamePolicy(nil, nil), ImportBatchSize: 10}
+	input := strings.Join([]string{
+		`{"auth0_id": "", "email": "jane@example.com"}`,
+		`{"auth0_id": "auth0|jane", "email": "not-an-email"}`,
+		`not json`,
+		``,
+		`{"auth0_id": "auth0|john", "email": "john@example.com", "username": "x"}`,
+	}, "\n")
+
+	reader, err := importer.NewReader(strings.NewReader(input), importer.FormatJSONL)
+	if err != nil {
+		t.Fatalf("NewReader() = %v", err)
+	}
+	report, err := s.RunImport(reader, ImportOptions{})
+	if err != nil {
+		t.Fatalf("RunImport() = %v", err)
+	}
+
+	if report.TotalRows != 4 || report.Failed != 4 || report.Created+report.Updated+report.Valid != 0 {
+		t.Errorf("RunImport() = %+v, want 4 failed rows of 4", report)
+	}
+	var lines []int32
+	for _, rowErr := range report.Errors {
+		lines = append(lines, rowErr.Line)
+	}
+	if want := []int32{1, 2, 3, 5}; !slices.Equal(lines, want) {
+		t.Errorf("RunImport() error lines = %v, want %v", lines, want)
+	}
+}
+
+func TestCountUpserts(t *testing.T) {
+	row := func(line int, auth0ID string) importRow {
+		return importRow{line: line, user: &models.User{Auth0ID: auth0ID}}
+	}
+	rows := []importRow{row(2, "auth0|new"), row(3, "auth0|existing"), row(4, "auth0|renamed")}
+	report := &pb.ImportUsersResponse{}
+
+	countUpserts(report, rows, map[string]bool{"auth0|new": true, "auth0|existing": false})
+
+	if report.Created != 1 || report.Updated != 1 || report.Failed != 1 {
+		t.Fatalf("countUpserts() = %+v, want 1 created, 1 updated, 1 failed", report)
+	}
+	if got := report.Errors[0]; got.Line != 4 || got.Error != errImportConcurrentChange.Error() {
+		t.Errorf("countUpserts() error = %+v, want line 4: %v", got, errImportConcurrentChange)
+	}
+}
+
+func TestIsIdentityConflict(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{repositories.ErrEmailTaken, true},
+		{fmt.Errorf("row 3: %w", repositories.ErrUsernameTaken), true},
+		{repositories.ErrAuth0IDTaken, true},
+		{errUsernameReserved, false},
+		{errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		if got := isIdentityConflict(tt.err); got != tt.want {
+			t.Errorf("isIdentityConflict(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+const minSearchQueryLength = 2
+
+// ✅ ListUsers - Admin listing with keyset pagination on (created_at, id)
+func (s *UserService) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Listing users | Domain: %s | Deleted: %s | Order: %s", req.EmailDomain, req.Deleted, req.Order)
+
+	params := repositories.ListUsersParams{
+		EmailDomain: strings.TrimPrefix(strings.TrimSpace(req.EmailDomain), "@"),
+		Descending:  req.Order == pb.SortOrder_SORT_ORDER_DESCENDING,
+		Limit:       pageSize(req.PageSize) + 1,
+	}
+
+	switch req.Deleted {
+	case pb.DeletedFilter_DELETED_FILTER_ONLY:
+		params.Deleted = repositories.OnlyDeleted
+	case pb.DeletedFilter_DELETED_FILTER_INCLUDE:
+		params.Deleted = repositories.IncludeDeleted
+	default:
+		params.Deleted = repositories.ExcludeDeleted
+	}
+
+	var err error
+	if params.CreatedAfter, err = requestTimestamp("created_after", req.CreatedAfter); err != nil {
+		return nil, err
+	}
+	if params.CreatedBefore, err = requestTimestamp("created_before", req.CreatedBefore); err != nil {
+		return nil, err
+	}
+
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		if uuid.Validate(token.ID) != nil {
+			return nil, errInvalidPageToken
+		}
+		params.After = &repositories.UserCursor{CreatedAt: token.Time, ID: token.ID}
+	}
+
+	users, err := s.Repo.ListUsers(params)
+	if err != nil {
+		log.Printf("❌ Failed to list users: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListUsersResponse{}
+	if len(users) == params.Limit {
+		users = users[:len(users)-1]
+		last := users[len(users)-1]
+		resp.NextPageToken = encodePageToken(pageToken{Time: last.CreatedAt, ID: last.ID})
+	}
+	for _, user := range users {
+		resp.Users = append(resp.Users, toUserResponse(user))
+	}
+
+	log.Printf("✅ Listed %d users", len(resp.Users))
+	return resp, nil
+}
+
+// ✅ SearchUsers - Admin search on username and email prefixes and trigram similarity
+func (s *UserService) SearchUsers(ctx context.Context, req *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	query := strings.TrimSpace(req.Query)
+	log.Printf("🔹 Searching users | Query: %s", query)
+
+	if len([]rune(query)) < minSearchQueryLength {
+		return nil, status.Errorf(codes.InvalidArgument, "query must be at least %d characters", minSearchQueryLength)
+	}
+
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	offset := 0
+	if token != nil {
+		offset = token.Offset
+	}
+
+	limit := pageSize(req.PageSize)
+	users, err := s.Repo.SearchUsers(query, offset, limit+1)
+	if err != nil {
+		log.Printf("❌ Failed to search users: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.SearchUsersResponse{}
+	if len(users) > limit {
+		users = users[:limit]
+		resp.NextPageToken = encodePageToken(pageToken{Offset: offset + limit})
+	}
+	for _, user := range users {
+		resp.Users = append(resp.Users, toUserResponse(user))
+	}
+
+	log.Printf("✅ Found %d users", len(resp.Users))
+	return resp, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestListUsersRejectsBeforeQuery(t *testing.T) {
+	s := &UserService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.ListUsersRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), &pb.ListUsersRequest{}, codes.Unauthenticated},
+		{"not an admin", callerContext("auth0|jane", auth.PermissionReadUserEmails), &pb.ListUsersRequest{}, codes.PermissionDenied},
+		{"created_after out of range", admin, &pb.ListUsersRequest{CreatedAfter: &timestamppb.Timestamp{Nanos: 1e9}}, codes.InvalidArgument},
+		{"malformed page token", admin, &pb.ListUsersRequest{PageToken: "!!!"}, codes.InvalidArgument},
+		{"page token without a cursor", admin, &pb.ListUsersRequest{PageToken: encodePageToken(pageToken{Offset: 50})}, codes.InvalidArgument},
+		{"page token cursor not a user id", admin, &pb.ListUsersRequest{PageToken: encodePageToken(pageToken{ID: "auth0|jane"})}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.ListUsers(tt.ctx, tt.req); status.Code(err) != tt.wantCode {
+				t.Errorf("ListUsers() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSearchUsersRejectsBeforeQuery(t *testing.T) {
+	s := &UserService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.SearchUsersRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), &pb.SearchUsersRequest{Query: "jane"}, codes.Unauthenticated},
+		{"not an admin", callerContext("auth0|jane"), &pb.SearchUsersRequest{Query: "jane"}, codes.PermissionDenied},
+		{"query too short", admin, &pb.SearchUsersRequest{Query: " j "}, codes.InvalidArgument},
+		{"malformed page token", admin, &pb.SearchUsersRequest{Query: "jane", PageToken: "!!!"}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.SearchUsers(tt.ctx, tt.req); status.Code(err) != tt.wantCode {
+				t.Errorf("SearchUsers() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
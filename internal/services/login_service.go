@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// Column limits from login_events; longer client-supplied values are truncated.
+const (
+	maxUserAgentLength  = 512
+	maxAppVersionLength = 50
+	maxAuthMethodLength = 50
+)
+
+// Bounds on a client-supplied occurred_at, so a bad clock cannot pin last_login_at in the future
+// or backfill history indefinitely.
+const (
+	maxLoginClockSkew = 5 * time.Minute
+	maxLoginAge       = 30 * 24 * time.Hour
+)
+
+// ✅ RecordLogin - Appends a login event and updates last_login_at
+func (s *UserService) RecordLogin(ctx context.Context, req *pb.RecordLoginRequest) (*pb.LoginEvent, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionRecordLogins)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IpAddress != "" {
+		// Only the login hook observes the real client address; users could claim any IP.
+		if err := requirePermission(ctx, auth.PermissionRecordLogins); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, "ip_address requires the %s permission", auth.PermissionRecordLogins)
+		}
+		if net.ParseIP(req.IpAddress) == nil {
+			return nil, status.Error(codes.InvalidArgument, "ip_address must be an IPv4 or IPv6 address")
+		}
+	}
+
+	event := &models.LoginEvent{
+		OccurredAt: time.Now().UTC(),
+		IPAddress:  stringPtr(firstNonEmpty(req.IpAddress, utils.ClientIP(ctx))),
+		UserAgent:  stringPtr(truncate(firstNonEmpty(req.UserAgent, utils.UserAgent(ctx)), maxUserAgentLength)),
+		AppVersion: stringPtr(truncate(req.AppVersion, maxAppVersionLength)),
+		AuthMethod: stringPtr(truncate(req.AuthMethod, maxAuthMethodLength)),
+	}
+	occurredAt, err := requestTimestamp("occurred_at", req.OccurredAt)
+	if err != nil {
+		return nil, err
+	}
+	if occurredAt != nil {
+		now := time.Now()
+		if occurredAt.After(now.Add(maxLoginClockSkew)) {
+			return nil, status.Error(codes.InvalidArgument, "occurred_at cannot be in the future")
+		}
+		if occurredAt.Before(now.Add(-maxLoginAge)) {
+			return nil, status.Error(codes.InvalidArgument, "occurred_at cannot be more than 30 days ago")
+		}
+		event.OccurredAt = *occurredAt
+	}
+
+	log.Printf("🔹 Recording login | Auth0ID: %s | Method: %s", auth0ID, req.AuthMethod)
+
+	if err := s.LoginRepo.RecordLogin(auth0ID, event); err != nil {
+		log.Printf("❌ Failed to record login: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	log.Printf("✅ Login recorded | Auth0ID: %s | EventID: %d", auth0ID, event.ID)
+	return toLoginEventResponse(event), nil
+}
+
+// ✅ ListLoginHistory - Pages through a user's logins, newest first
+func (s *UserService) ListLoginHistory(ctx context.Context, req *pb.ListLoginHistoryRequest) (*pb.ListLoginHistoryResponse, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Listing login history | Auth0ID: %s", auth0ID)
+
+	user, err := s.Repo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	var after *repositories.LoginCursor
+	if token != nil {
+		id, err := strconv.ParseInt(token.ID, 10, 64)
+		if err != nil {
+			return nil, errInvalidPageToken
+		}
+		after = &repositories.LoginCursor{OccurredAt: token.Time, ID: id}
+	}
+
+	limit := pageSize(req.PageSize)
+	events, err := s.LoginRepo.ListLoginEvents(user.ID, after, limit+1)
+	if err != nil {
+		log.Printf("❌ Failed to list login history: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListLoginHistoryResponse{}
+	if len(events) > limit {
+		events = events[:limit]
+		last := events[len(events)-1]
+		resp.NextPageToken = encodePageToken(pageToken{Time: last.OccurredAt, ID: strconv.FormatInt(last.ID, 10)})
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toLoginEventResponse(event))
+	}
+
+	log.Printf("✅ Listed %d login events", len(resp.Events))
+	return resp, nil
+}
+
+// toLoginEventResponse converts a login event model into its protobuf representation.
+func toLoginEventResponse(event *models.LoginEvent) *pb.LoginEvent {
+	return &pb.LoginEvent{
+		Id:         event.ID,
+		UserId:     event.UserID,
+		OccurredAt: utils.ToProtoTimestamp(event.OccurredAt),
+		IpAddress:  derefString(event.IPAddress),
+		UserAgent:  derefString(event.UserAgent),
+		AppVersion: derefString(event.AppVersion),
+		AuthMethod: derefString(event.AuthMethod),
+	}
+}
+
+// firstNonEmpty returns the first non-blank value.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// truncate shortens s to at most max bytes without splitting a UTF-8 sequence.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[:max]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestRecordLoginRejectsBeforeRecording(t *testing.T) {
+	s := &UserService{}
+	jane := callerContext("auth0|jane")
+	hook := callerContext("auth0|hook", auth.PermissionRecordLogins)
+	at := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(time.Now().Add(d)) }
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.RecordLoginRequest
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), &pb.RecordLoginRequest{Auth0Id: "auth0|jane"}, codes.Unauthenticated},
+		{"another user", jane, &pb.RecordLoginRequest{Auth0Id: "auth0|john"}, codes.PermissionDenied},
+		{"ip address from a user", jane, &pb.RecordLoginRequest{IpAddress: "203.0.113.7"}, codes.PermissionDenied},
+		{"malformed ip address", hook, &pb.RecordLoginRequest{Auth0Id: "auth0|jane", IpAddress: "203.0.113"}, codes.InvalidArgument},
+		{"occurred_at out of range", jane, &pb.RecordLoginRequest{OccurredAt: &timestamppb.Timestamp{Seconds: -1e12}}, codes.InvalidArgument},
+		{"occurred_at in the future", jane, &pb.RecordLoginRequest{OccurredAt: at(time.Hour)}, codes.InvalidArgument},
+		{"occurred_at too old", jane, &pb.RecordLoginRequest{OccurredAt: at(-31 * 24 * time.Hour)}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.RecordLogin(tt.ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("RecordLogin() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestListLoginHistoryRejectsBeforeLookup(t *testing.T) {
+	s := &UserService{}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		auth0ID  string
+		wantCode codes.Code
+	}{
+		{"unauthenticated", context.Background(), "auth0|jane", codes.Unauthenticated},
+		{"another user", callerContext("auth0|jane", auth.PermissionRecordLogins), "auth0|john", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ListLoginHistory(tt.ctx, &pb.ListLoginHistoryRequest{Auth0Id: tt.auth0ID})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("ListLoginHistory() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		max  int
+		want string
+	}{
+		{"iPhone", 10, "iPhone"},
+		{"iPhone", 3, "iPh"},
+		{"café", 4, "caf"},
+		{"café", 5, "café"},
+		{"", 3, ""},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.s, tt.max); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestFirstNonEmpty(t *testing.T) {
+	if got := firstNonEmpty("", "  ", " 203.0.113.7 ", "198.51.100.1"); got != "203.0.113.7" {
+		t.Errorf("firstNonEmpty() = %q, want 203.0.113.7", got)
+	}
+	if got := firstNonEmpty("", " "); got != "" {
+		t.Errorf("firstNonEmpty() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// restrictionRefreshInterval is how long the cached list of suspended and banned users is used,
+// i.e. how long a moderation decision made by another instance may take to apply.
+const restrictionRefreshInterval = 30 * time.Second
+
+// suspensionExpiryActor is the audit log actor for suspensions lifted because they expired.
+var suspensionExpiryActor = "system:suspension-expiry"
+
+// accountStatuses maps stored account statuses to their protobuf values.
+var accountStatuses = map[string]pb.AccountStatus{
+	models.UserStatusActive:              pb.AccountStatus_ACCOUNT_STATUS_ACTIVE,
+	models.UserStatusSuspended:           pb.AccountStatus_ACCOUNT_STATUS_SUSPENDED,
+	models.UserStatusBanned:              pb.AccountStatus_ACCOUNT_STATUS_BANNED,
+	models.UserStatusPendingVerification: pb.AccountStatus_ACCOUNT_STATUS_PENDING_VERIFICATION,
+}
+
+// ModerationService suspends and bans accounts, and tells the auth interceptor whose account
+// may not be used.
+type ModerationService struct {
+	Repo  *repositories.UserRepository
+	Audit *AuditService
+	Roles *RoleService // Tells whether a target holds admin:users through a local role
+
+	restricted *refreshingCache[map[string]*models.User] // Suspended and banned users, by Auth0 ID
+}
+
+// NewModerationService creates a new ModerationService instance.
+func NewModerationService(repo *repositories.UserRepository, audit *AuditService, roles *RoleService) *ModerationService {
+	s := &ModerationService{
+		Repo:  repo,
+		Audit: audit,
+		Roles: roles,
+	}
+	s.restricted = newRefreshingCache(restrictionRefreshInterval, s.loadRestrictions)
+	return s
+}
+
+// ✅ SuspendUser
+func (s *ModerationService) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.UserResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionModerateUsers); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateAuth0ID(req.Auth0Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Auth0Id == callerSubject(ctx) {
+		return nil, status.Error(codes.FailedPrecondition, "moderators cannot suspend themselves")
+	}
+	// A restricted admin could not lift the restriction again, so only admins may restrict admins.
+	if !auth.FromContext(ctx).IsAdmin() {
+		permissions, err := s.Roles.Permissions(ctx, req.Auth0Id)
+		if err != nil {
+			log.Printf("❌ Failed to retrieve permissions: %v", err)
+			return nil, err
+		}
+		if slices.Contains(permissions, auth.PermissionAdmin) {
+			return nil, status.Errorf(codes.PermissionDenied, "restricting an admin requires the %s permission", auth.PermissionAdmin)
+		}
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len(reason) > 500 {
+		return nil, status.Error(codes.InvalidArgument, "reason must be between 1 and 500 characters")
+	}
+
+	change := models.UserStatusChange{
+		Status:    models.UserStatusSuspended,
+		Reason:    &reason,
+		ChangedBy: stringPtr(callerSubject(ctx)),
+	}
+	action := models.AuditUserSuspended
+	if req.Ban {
+		if req.ExpiresAt != nil {
+			return nil, status.Error(codes.InvalidArgument, "bans cannot have expires_at")
+		}
+		change.Status = models.UserStatusBanned
+		action = models.AuditUserBanned
+	} else if req.ExpiresAt != nil {
+		expiresAt, err := requestTimestamp("expires_at", req.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if !expiresAt.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		change.ExpiresAt = expiresAt
+	}
+
+	log.Printf("🔹 Restricting user | Auth0ID: %s | Status: %s", req.Auth0Id, change.Status)
+
+	user, err := s.Repo.SetUserStatus(req.Auth0Id, change, action, auditContext(ctx))
+	if err != nil {
+		log.Printf("❌ Failed to restrict user: %v", err)
+		return nil, toStatusError(err)
+	}
+	s.remember(user)
+
+	log.Printf("✅ User restricted | Auth0ID: %s | Status: %s", user.Auth0ID, user.Status)
+	return toUserResponse(user), nil
+}
+
+// ✅ UnsuspendUser
+func (s *ModerationService) UnsuspendUser(ctx context.Context, req *pb.UnsuspendUserRequest) (*pb.UserResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionModerateUsers); err != nil {
+		return nil, err
+	}
+	if err := utils.ValidateAuth0ID(req.Auth0Id); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	log.Printf("🔹 Lifting restriction | Auth0ID: %s", req.Auth0Id)
+
+	user, err := s.Repo.GetUser(req.Auth0Id)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+	// Other states (e.g. pending_verification) are not moderation decisions, so they are kept.
+	if user.Status != models.UserStatusSuspended && user.Status != models.UserStatusBanned {
+		return toUserResponse(user), nil
+	}
+
+	change := models.UserStatusChange{Status: models.UserStatusActive, ChangedBy: stringPtr(callerSubject(ctx))}
+	user, err = s.Repo.SetUserStatus(req.Auth0Id, change, models.AuditUserUnsuspended, auditContext(ctx))
+	if err != nil {
+		log.Printf("❌ Failed to lift restriction: %v", err)
+		return nil, toStatusError(err)
+	}
+	s.remember(user)
+
+	log.Printf("✅ Restriction lifted | Auth0ID: %s", user.Auth0ID)
+	return toUserResponse(user), nil
+}
+
+// AccountRestriction implements interceptors.AccountStatusChecker. Suspensions stop applying as
+// soon as they expire, even before RunSuspensionLifter reactivates the account.
+func (s *ModerationService) AccountRestriction(ctx context.Context, subject string) (string, error) {
+	restricted, err := s.restricted.get()
+	if err != nil {
+		return "", err
+	}
+
+	user, ok := restricted[subject]
+	if !ok || !user.IsRestricted(time.Now()) {
+		return "", nil
+	}
+	return describeRestriction(user), nil
+}
+
+// loadRestrictions loads the suspended and banned users.
+func (s *ModerationService) loadRestrictions() (map[string]*models.User, error) {
+	users, err := s.Repo.ListRestrictedUsers()
+	if err != nil {
+		return nil, err
+	}
+	restricted := make(map[string]*models.User, len(users))
+	for _, user := range users {
+		restricted[user.Auth0ID] = user
+	}
+	return restricted, nil
+}
+
+// RunSuspensionLifter reactivates users whose suspension expired every interval until ctx is cancelled.
+func (s *ModerationService) RunSuspensionLifter(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		lifted, err := s.Repo.LiftExpiredSuspensions(models.AuditEvent{ActorSubject: &suspensionExpiryActor})
+		if err != nil {
+			log.Printf("❌ Failed to lift expired suspensions: %v", err)
+			return
+		}
+		for _, user := range lifted {
+			s.remember(user)
+		}
+		if len(lifted) > 0 {
+			log.Printf("✅ Lifted %d expired suspensions", len(lifted))
+		}
+	})
+}
+
+// remember updates the cached restriction of a user after a status change on this instance.
+func (s *ModerationService) remember(user *models.User) {
+	s.restricted.update(func(current map[string]*models.User) map[string]*models.User {
+		restricted := maps.Clone(current)
+		if restricted == nil {
+			restricted = make(map[string]*models.User)
+		}
+		if user.Status == models.UserStatusSuspended || user.Status == models.UserStatusBanned {
+			restricted[user.Auth0ID] = user
+		} else {
+			delete(restricted, user.Auth0ID)
+		}
+		return restricted
+	})
+}
+
+// describeRestriction is the message returned to a restricted user.
+func describeRestriction(user *models.User) string {
+	message := "account is banned"
+	if user.Status == models.UserStatusSuspended {
+		message = "account is suspended"
+		if user.StatusExpiresAt != nil {
+			message += " until " + utils.FormatTimestamp(*user.StatusExpiresAt)
+		}
+	}
+	if user.StatusReason != nil {
+		message = fmt.Sprintf("%s: %s", message, *user.StatusReason)
+	}
+	return message
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestModerationRejections(t *testing.T) {
+	roles := &RoleService{}
+	roles.permissions = newRefreshingCache(time.Hour, func() (map[string][]string, error) {
+		return map[string][]string{"auth0|boss": {auth.PermissionAdmin}}, nil
+	})
+	s := &ModerationService{Roles: roles}
+	moderator := callerContext("auth0|mod", auth.PermissionModerateUsers)
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+	yesterday := timestamppb.New(time.Now().Add(-24 * time.Hour))
+	tomorrow := timestamppb.New(time.Now().Add(24 * time.Hour))
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{"suspend unauthenticated", func() error {
+			_, err := s.SuspendUser(context.Background(), &pb.SuspendUserRequest{Auth0Id: "auth0|jane", Reason: "spam"})
+			return err
+		}, codes.Unauthenticated},
+		{"suspend without moderate:users", func() error {
+			_, err := s.SuspendUser(callerContext("auth0|john"), &pb.SuspendUserRequest{Auth0Id: "auth0|jane", Reason: "spam"})
+			return err
+		}, codes.PermissionDenied},
+		{"suspend without auth0_id", func() error {
+			_, err := s.SuspendUser(moderator, &pb.SuspendUserRequest{Reason: "spam"})
+			return err
+		}, codes.InvalidArgument},
+		{"suspend themselves", func() error {
+			_, err := s.SuspendUser(moderator, &pb.SuspendUserRequest{Auth0Id: "auth0|mod", Reason: "spam"})
+			return err
+		}, codes.FailedPrecondition},
+		{"moderator suspends an admin", func() error {
+			_, err := s.SuspendUser(moderator, &pb.SuspendUserRequest{Auth0Id: "auth0|boss", Reason: "spam"})
+			return err
+		}, codes.PermissionDenied},
+		{"suspend without a reason", func() error {
+			_, err := s.SuspendUser(moderator, &pb.SuspendUserRequest{Auth0Id: "auth0|jane", Reason: " "})
+			return err
+		}, codes.InvalidArgument},
+		{"admin suspends an admin without a reason", func() error {
+			_, err := s.SuspendUser(admin, &pb.SuspendUserRequest{Auth0Id: "auth0|boss"})
+			return err
+		}, codes.InvalidArgument},
+		{"ban with an expiry", func() error {
+			_, err := s.SuspendUser(moderator, &pb.SuspendUserRequest{Auth0Id: "auth0|jane", Reason: "spam", Ban: true, ExpiresAt: tomorrow})
+			return err
+		}, codes.InvalidArgument},
+		{"suspend until the past", func() error {
+			_, err := s.SuspendUser(moderator, &pb.SuspendUserRequest{Auth0Id: "auth0|jane", Reason: "spam", ExpiresAt: yesterday})
+			return err
+		}, codes.InvalidArgument},
+		{"unsuspend without moderate:users", func() error {
+			_, err := s.UnsuspendUser(callerContext("auth0|john"), &pb.UnsuspendUserRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.PermissionDenied},
+		{"unsuspend without auth0_id", func() error {
+			_, err := s.UnsuspendUser(moderator, &pb.UnsuspendUserRequest{})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDescribeRestriction(t *testing.T) {
+	until := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		user *models.User
+		want string
+	}{
+		{"banned", &models.User{Status: models.UserStatusBanned}, "account is banned"},
+		{"banned with reason", &models.User{Status: models.UserStatusBanned, StatusReason: stringPtr("fraud")}, "account is banned: fraud"},
+		{"suspended indefinitely", &models.User{Status: models.UserStatusSuspended}, "account is suspended"},
+		{"suspended until", &models.User{Status: models.UserStatusSuspended, StatusExpiresAt: &until, StatusReason: stringPtr("spam")}, "account is suspended until 2026-11-01T00:00:00Z: spam"},
+		{"ban ignores expiry", &models.User{Status: models.UserStatusBanned, StatusExpiresAt: &until}, "account is banned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeRestriction(tt.user); got != tt.want {
+				t.Errorf("describeRestriction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountRestriction(t *testing.T) {
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	s := &ModerationService{}
+	s.restricted = newRefreshingCache(time.Hour, func() (map[string]*models.User, error) {
+		return map[string]*models.User{
+			"auth0|banned":    {Auth0ID: "auth0|banned", Status: models.UserStatusBanned},
+			"auth0|suspended": {Auth0ID: "auth0|suspended", Status: models.UserStatusSuspended, StatusExpiresAt: &future},
+			"auth0|expired":   {Auth0ID: "auth0|expired", Status: models.UserStatusSuspended, StatusExpiresAt: &past},
+			"auth0|lifted":    {Auth0ID: "auth0|lifted", Status: models.UserStatusBanned},
+		}, nil
+	})
+	if _, err := s.AccountRestriction(context.Background(), "auth0|banned"); err != nil {
+		t.Fatalf("AccountRestriction() = %v", err)
+	}
+	// Local changes apply without a reload.
+	s.remember(&models.User{Auth0ID: "auth0|lifted", Status: models.UserStatusActive})
+	s.remember(&models.User{Auth0ID: "auth0|late", Status: models.UserStatusBanned})
+
+	tests := []struct {
+		subject        string
+		wantRestricted bool
+	}{
+		{"auth0|banned", true},
+		{"auth0|suspended", true},
+		{"auth0|expired", false},
+		{"auth0|lifted", false},
+		{"auth0|late", true},
+		{"auth0|unknown", false},
+	}
+	for _, tt := range tests {
+		message, err := s.AccountRestriction(context.Background(), tt.subject)
+		if err != nil {
+			t.Fatalf("AccountRestriction(%q) = %v", tt.subject, err)
+		}
+		if (message != "") != tt.wantRestricted {
+			t.Errorf("AccountRestriction(%q) = %q, want restricted: %v", tt.subject, message, tt.wantRestricted)
+		}
+	}
+}
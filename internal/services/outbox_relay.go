@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/events"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+)
+
+// OutboxRelay publishes the user lifecycle events written to the outbox by UserService mutations.
+type OutboxRelay struct {
+	Repo      *repositories.OutboxRepository
+	Publisher events.EventPublisher
+	BatchSize int
+	Retention time.Duration // How long published events are kept for replay
+}
+
+// NewOutboxRelay creates a new OutboxRelay instance.
+func NewOutboxRelay(repo *repositories.OutboxRepository, publisher events.EventPublisher, cfg *config.Config) *OutboxRelay {
+	return &OutboxRelay{
+		Repo:      repo,
+		Publisher: publisher,
+		BatchSize: max(cfg.OutboxBatchSize, 1),
+		Retention: cfg.OutboxRetention,
+	}
+}
+
+// Run publishes pending events every interval until ctx is cancelled.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		if _, err := r.PublishPending(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("❌ Failed to publish outbox events: %v", err)
+		}
+	})
+}
+
+// PublishPending publishes every pending event, a batch at a time, and returns how many were published.
+func (r *OutboxRelay) PublishPending(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		published, err := r.Repo.PublishPending(ctx, r.BatchSize, func(event *models.OutboxEvent) error {
+			return r.Publisher.Publish(ctx, event)
+		})
+		total += published
+		if err != nil || published < r.BatchSize {
+			return total, err
+		}
+	}
+}
+
+// RunGarbageCollector deletes published events past the retention period every interval until ctx is cancelled.
+func (r *OutboxRelay) RunGarbageCollector(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func() {
+		deleted, err := r.Repo.DeletePublishedBefore(time.Now().Add(-r.Retention).UTC())
+		if err != nil {
+			log.Printf("❌ Failed to garbage-collect outbox events: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("✅ Garbage-collected %d published outbox events", deleted)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+var errInvalidPageToken = status.Error(codes.InvalidArgument, "invalid page_token")
+
+// pageToken is the decoded form of the opaque page tokens handed to clients.
+// Keyset listings use Time/ID (e.g. created_at, id); ranked searches use Offset.
+type pageToken struct {
+	Time   time.Time `json:"c,omitempty"`
+	ID     string    `json:"i,omitempty"`
+	Offset int       `json:"o,omitempty"`
+}
+
+// encodePageToken serializes a page token.
+func encodePageToken(token pageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken parses a page token, returning nil for an empty one.
+func decodePageToken(raw string) (*pageToken, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, errInvalidPageToken
+	}
+	return &token, nil
+}
+
+// pageSize clamps a requested page size to [1, maxPageSize], defaulting when unset.
+func pageSize(requested int32) int {
+	switch {
+	case requested <= 0:
+		return defaultPageSize
+	case requested > maxPageSize:
+		return maxPageSize
+	default:
+		return int(requested)
+	}
+}
+
+// requestTimestamp converts an optional Timestamp request field, rejecting out-of-range values.
+func requestTimestamp(field string, ts *timestamppb.Timestamp) (*time.Time, error) {
+	t, err := utils.FromOptionalProtoTimestamp(ts)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a valid timestamp", field)
+	}
+	return t, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		token pageToken
+	}{
+		{"keyset", pageToken{Time: time.Date(2026, 3, 1, 12, 30, 0, 123456789, time.UTC), ID: "auth0|abc"}},
+		{"offset", pageToken{Offset: 150}},
+		{"empty", pageToken{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageToken(encodePageToken(tt.token))
+			if err != nil {
+				t.Fatalf("decodePageToken() = %v", err)
+			}
+			if !got.Time.Equal(tt.token.Time) || got.ID != tt.token.ID || got.Offset != tt.token.Offset {
+				t.Errorf("decodePageToken() = %+v, want %+v", *got, tt.token)
+			}
+		})
+	}
+}
+
+func TestDecodePageToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "empty", raw: "", wantNil: true},
+		{name: "not base64", raw: "!!!", wantErr: true},
+		{name: "not json", raw: base64.RawURLEncoding.EncodeToString([]byte("page 2")), wantErr: true},
+		{name: "wrong field type", raw: base64.RawURLEncoding.EncodeToString([]byte(`{"o":"ten"}`)), wantErr: true},
+		{name: "padded base64", raw: base64.URLEncoding.EncodeToString([]byte(`{"o":1}`)), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePageToken(tt.raw)
+			if tt.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Errorf("decodePageToken(%q) error = %v, want InvalidArgument", tt.raw, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodePageToken(%q) = %v", tt.raw, err)
+			}
+			if (got == nil) != tt.wantNil {
+				t.Errorf("decodePageToken(%q) = %+v, want nil: %v", tt.raw, got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		requested int32
+		want      int
+	}{
+		{-1, defaultPageSize},
+		{0, defaultPageSize},
+		{1, 1},
+		{maxPageSize, maxPageSize},
+		{maxPageSize + 1, maxPageSize},
+	}
+	for _, tt := range tests {
+		if got := pageSize(tt.requested); got != tt.want {
+			t.Errorf("pageSize(%d) = %d, want %d", tt.requested, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// profileField pairs an optional request field with its validator.
+type profileField struct {
+	name     string
+	value    *string
+	validate func(string) error
+	target   **string
+}
+
+// profileUpdateFromRequest normalizes and validates the fields set on an UpdateUserRequest.
+func profileUpdateFromRequest(req *pb.UpdateUserRequest) (models.UserProfileUpdate, error) {
+	var update models.UserProfileUpdate
+
+	if email := utils.NormalizeEmail(req.Email); email != "" {
+		if err := utils.ValidateEmail(email); err != nil {
+			return update, status.Errorf(codes.InvalidArgument, "email: %v", err)
+		}
+		update.Email = &email
+	}
+
+	fields := []profileField{
+		{"display_name", req.DisplayName, utils.ValidateDisplayName, &update.DisplayName},
+		{"avatar_url", req.AvatarUrl, utils.ValidateAvatarURL, &update.AvatarURL},
+		{"bio", req.Bio, utils.ValidateBio, &update.Bio},
+		{"locale", req.Locale, utils.ValidateLocale, &update.Locale},
+		{"timezone", req.Timezone, utils.ValidateTimezone, &update.Timezone},
+		{"date_of_birth", req.DateOfBirth, utils.ValidateDateOfBirth, &update.DateOfBirth},
+	}
+
+	changed := update.Email != nil
+	for _, field := range fields {
+		if field.value == nil {
+			continue
+		}
+		value := strings.TrimSpace(*field.value)
+		if value != "" {
+			if err := field.validate(value); err != nil {
+				return update, status.Errorf(codes.InvalidArgument, "%s: %v", field.name, err)
+			}
+		}
+		*field.target = &value
+		changed = true
+	}
+
+	if !changed {
+		return update, status.Error(codes.InvalidArgument, "at least one field to update is required")
+	}
+	return update, nil
+}
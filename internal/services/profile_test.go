@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestProfileUpdateFromRequest(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		req      *pb.UpdateUserRequest
+		wantCode codes.Code
+	}{
+		{"nothing to update", &pb.UpdateUserRequest{Auth0Id: "auth0|jane"}, codes.InvalidArgument},
+		{"email", &pb.UpdateUserRequest{Email: " Jane@Example.com "}, codes.OK},
+		{"invalid email", &pb.UpdateUserRequest{Email: "jane@example"}, codes.InvalidArgument},
+		{"clear a field", &pb.UpdateUserRequest{Bio: str("  ")}, codes.OK},
+		{"display name", &pb.UpdateUserRequest{DisplayName: str("Jane Doe")}, codes.OK},
+		{"display name with newline", &pb.UpdateUserRequest{DisplayName: str("Jane\nDoe")}, codes.InvalidArgument},
+		{"avatar url over http", &pb.UpdateUserRequest{AvatarUrl: str("http://cdn.example.com/a.png")}, codes.InvalidArgument},
+		{"unknown timezone", &pb.UpdateUserRequest{Timezone: str("Mars/Olympus")}, codes.InvalidArgument},
+		{"date of birth not a date", &pb.UpdateUserRequest{DateOfBirth: str("01/02/1990")}, codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := profileUpdateFromRequest(tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("profileUpdateFromRequest() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestProfileUpdateFromRequestNormalizes(t *testing.T) {
+	bio := "  Drummer  "
+	empty := ""
+	update, err := profileUpdateFromRequest(&pb.UpdateUserRequest{Email: " Jane@Example.COM ", Bio: &bio, Locale: &empty})
+	if err != nil {
+		t.Fatalf("profileUpdateFromRequest() = %v", err)
+	}
+
+	if update.Email == nil || *update.Email != "jane@example.com" {
+		t.Errorf("Email = %v, want jane@example.com", update.Email)
+	}
+	if update.Bio == nil || *update.Bio != "Drummer" {
+		t.Errorf("Bio = %v, want Drummer", update.Bio)
+	}
+	if update.Locale == nil || *update.Locale != "" {
+		t.Errorf("Locale = %v, want an empty value that clears it", update.Locale)
+	}
+	if update.DisplayName != nil {
+		t.Errorf("DisplayName = %q, want unset", *update.DisplayName)
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// rolePermissionsRefreshInterval is how long cached role permissions are used, i.e. how long
+// an assignment or revocation made by another instance may take to apply.
+const rolePermissionsRefreshInterval = 30 * time.Second
+
+// RoleSyncer mirrors local role assignments to the identity provider.
+type RoleSyncer interface {
+	AssignRoles(ctx context.Context, userID string, roleIDs []string) error
+	RemoveRoles(ctx context.Context, userID string, roleIDs []string) error
+}
+
+// RoleService manages local roles and supplies their permissions to the auth interceptor.
+type RoleService struct {
+	Repo     *repositories.RoleRepository
+	UserRepo *repositories.UserRepository
+	Audit    *AuditService
+	Syncer   RoleSyncer // Optional; roles with an Auth0 role ID are mirrored when set
+
+	permissions *refreshingCache[map[string][]string] // Permissions granted by local roles, by Auth0 ID
+}
+
+// NewRoleService creates a new RoleService instance. syncer may be nil.
+func NewRoleService(repo *repositories.RoleRepository, userRepo *repositories.UserRepository, audit *AuditService, syncer RoleSyncer) *RoleService {
+	s := &RoleService{
+		Repo:     repo,
+		UserRepo: userRepo,
+		Audit:    audit,
+		Syncer:   syncer,
+	}
+	s.permissions = newRefreshingCache(rolePermissionsRefreshInterval, repo.ListRolePermissions)
+	return s
+}
+
+// ✅ AssignRole
+func (s *RoleService) AssignRole(ctx context.Context, req *pb.AssignRoleRequest) (*pb.UserRole, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	user, role, err := s.resolveAssignment(req.Auth0Id, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Assigning role | Auth0ID: %s | Role: %s", user.Auth0ID, role.Name)
+
+	assigned, err := s.Repo.AssignRole(user.ID, role.Name, stringPtr(callerSubject(ctx)))
+	if err != nil {
+		log.Printf("❌ Failed to assign role: %v", err)
+		return nil, err
+	}
+	if assigned {
+		s.permissions.invalidate()
+		if err := s.Audit.Record(ctx, models.AuditRoleAssigned, user.Auth0ID, map[string]string{"role": role.Name}); err != nil {
+			return nil, err
+		}
+		// Local roles already grant the permissions, so a failed assignment in Auth0 is only logged.
+		_ = s.sync(ctx, user.Auth0ID, role, true)
+	}
+
+	roles, err := s.Repo.ListUserRoles(user.ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve roles: %v", err)
+		return nil, err
+	}
+	for _, userRole := range roles {
+		if userRole.Name == role.Name {
+			log.Printf("✅ Role assigned | Auth0ID: %s | Role: %s | New: %t", user.Auth0ID, role.Name, assigned)
+			return toUserRoleResponse(userRole), nil
+		}
+	}
+	// Revoked again before we could read it back.
+	return nil, status.Error(codes.Aborted, "role assignment changed concurrently")
+}
+
+// ✅ RevokeRole
+func (s *RoleService) RevokeRole(ctx context.Context, req *pb.RevokeRoleRequest) (*pb.RevokeRoleResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	user, role, err := s.resolveAssignment(req.Auth0Id, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Revoking role | Auth0ID: %s | Role: %s", user.Auth0ID, role.Name)
+
+	// Tokens carry the Auth0 roles' permissions too, so the role is removed there first: if that
+	// fails, nothing is revoked and the admin can retry, instead of the user keeping the permissions.
+	if err := s.sync(ctx, user.Auth0ID, role, false); err != nil {
+		return nil, status.Error(codes.Unavailable, "failed to remove the role in Auth0; nothing was revoked")
+	}
+
+	revoked, err := s.Repo.RevokeRole(user.ID, role.Name)
+	if err != nil {
+		log.Printf("❌ Failed to revoke role: %v", err)
+		return nil, err
+	}
+	if !revoked {
+		return &pb.RevokeRoleResponse{Message: "User did not have the role"}, nil
+	}
+	s.permissions.invalidate()
+	if err := s.Audit.Record(ctx, models.AuditRoleRevoked, user.Auth0ID, map[string]string{"role": role.Name}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Role revoked | Auth0ID: %s | Role: %s", user.Auth0ID, role.Name)
+	return &pb.RevokeRoleResponse{Message: "Role revoked successfully"}, nil
+}
+
+// ✅ ListUserRoles
+func (s *RoleService) ListUserRoles(ctx context.Context, req *pb.ListUserRolesRequest) (*pb.ListUserRolesResponse, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Listing roles | Auth0ID: %s", auth0ID)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+	roles, err := s.Repo.ListUserRoles(user.ID)
+	if err != nil {
+		log.Printf("❌ Failed to list roles: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListUserRolesResponse{Auth0Id: auth0ID}
+	for _, role := range roles {
+		resp.Roles = append(resp.Roles, toUserRoleResponse(role))
+	}
+
+	log.Printf("✅ Roles listed | Auth0ID: %s | Count: %d", auth0ID, len(resp.Roles))
+	return resp, nil
+}
+
+// Permissions implements interceptors.PermissionSource: the permissions granted by the caller's
+// local roles, cached for rolePermissionsRefreshInterval.
+func (s *RoleService) Permissions(ctx context.Context, subject string) ([]string, error) {
+	permissions, err := s.permissions.get()
+	if err != nil {
+		return nil, err
+	}
+	return permissions[subject], nil
+}
+
+// resolveAssignment validates an assignment request and loads its user and role.
+func (s *RoleService) resolveAssignment(auth0ID, name string) (*models.User, *models.Role, error) {
+	if err := utils.ValidateAuth0ID(auth0ID); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "role is required")
+	}
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, nil, toStatusError(err)
+	}
+	role, err := s.Repo.GetRole(name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, status.Errorf(codes.NotFound, "role %q not found", name)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, role, nil
+}
+
+// sync mirrors an assignment to the identity provider, logging and returning failures.
+func (s *RoleService) sync(ctx context.Context, auth0ID string, role *models.Role, assigned bool) error {
+	if s.Syncer == nil || role.Auth0RoleID == nil {
+		return nil
+	}
+	var err error
+	if assigned {
+		err = s.Syncer.AssignRoles(ctx, auth0ID, []string{*role.Auth0RoleID})
+	} else {
+		err = s.Syncer.RemoveRoles(ctx, auth0ID, []string{*role.Auth0RoleID})
+	}
+	if err != nil {
+		log.Printf("❌ Failed to sync role to Auth0 | Auth0ID: %s | Role: %s: %v", auth0ID, role.Name, err)
+	}
+	return err
+}
+
+// toUserRoleResponse converts a models.UserRole to a protobuf message.
+func toUserRoleResponse(role *models.UserRole) *pb.UserRole {
+	return &pb.UserRole{
+		Role:        role.Name,
+		Description: derefString(role.Description),
+		Permissions: role.Permissions,
+		GrantedBy:   derefString(role.GrantedBy),
+		GrantedAt:   utils.ToProtoTimestamp(role.GrantedAt),
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestRoleRejections(t *testing.T) {
+	s := &RoleService{}
+	admin := callerContext("auth0|admin", auth.PermissionAdmin)
+	support := callerContext("auth0|support", auth.PermissionReadUserEmails)
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{"assign unauthenticated", func() error {
+			_, err := s.AssignRole(context.Background(), &pb.AssignRoleRequest{Auth0Id: "auth0|jane", Role: "support"})
+			return err
+		}, codes.Unauthenticated},
+		{"assign without admin", func() error {
+			_, err := s.AssignRole(support, &pb.AssignRoleRequest{Auth0Id: "auth0|support", Role: "admin"})
+			return err
+		}, codes.PermissionDenied},
+		{"assign without auth0_id", func() error {
+			_, err := s.AssignRole(admin, &pb.AssignRoleRequest{Role: "support"})
+			return err
+		}, codes.InvalidArgument},
+		{"assign without a role", func() error {
+			_, err := s.AssignRole(admin, &pb.AssignRoleRequest{Auth0Id: "auth0|jane", Role: " "})
+			return err
+		}, codes.InvalidArgument},
+		{"revoke without admin", func() error {
+			_, err := s.RevokeRole(support, &pb.RevokeRoleRequest{Auth0Id: "auth0|jane", Role: "support"})
+			return err
+		}, codes.PermissionDenied},
+		{"revoke without a role", func() error {
+			_, err := s.RevokeRole(admin, &pb.RevokeRoleRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.InvalidArgument},
+		{"list unauthenticated", func() error {
+			_, err := s.ListUserRoles(context.Background(), &pb.ListUserRolesRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.Unauthenticated},
+		{"list another user's roles", func() error {
+			_, err := s.ListUserRoles(support, &pb.ListUserRolesRequest{Auth0Id: "auth0|jane"})
+			return err
+		}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+// revocationRefreshInterval is how stale the cached revocation list may get before it is reloaded,
+// i.e. how long a session revoked on another instance can keep working here.
+const revocationRefreshInterval = 30 * time.Second
+
+// SessionService manages the devices users are signed in on and tracks revoked token families.
+type SessionService struct {
+	Repo     *repositories.SessionRepository
+	UserRepo *repositories.UserRepository
+	Audit    *AuditService
+	Lookback time.Duration // How far back revocations matter; must cover the refresh token lifetime
+
+	revoked *refreshingCache[map[repositories.RevokedFamily]struct{}]
+}
+
+// NewSessionService creates a new SessionService instance.
+func NewSessionService(repo *repositories.SessionRepository, userRepo *repositories.UserRepository, audit *AuditService, cfg *config.Config) *SessionService {
+	s := &SessionService{
+		Repo:     repo,
+		UserRepo: userRepo,
+		Audit:    audit,
+		Lookback: cfg.RefreshTokenMaxLifetime,
+	}
+	s.revoked = newRefreshingCache(revocationRefreshInterval, s.loadRevocations)
+	return s
+}
+
+// ✅ RegisterSession
+func (s *SessionService) RegisterSession(ctx context.Context, req *pb.RegisterSessionRequest) (*pb.Session, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if req.DeviceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_id is required")
+	}
+
+	log.Printf("🔹 Registering session | Auth0ID: %s | Device: %s", auth0ID, req.DeviceId)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	// The family only ever comes from the verified token, so a session cannot be registered
+	// (and then revoked) under someone else's sid.
+	family := ""
+	if claims := auth.FromContext(ctx); claims.Subject == auth0ID {
+		family = claims.SessionID
+	}
+
+	session, err := s.Repo.UpsertSession(&models.Session{
+		ID:          uuid.NewString(),
+		UserID:      user.ID,
+		DeviceID:    truncate(req.DeviceId, 255),
+		TokenFamily: stringPtr(family),
+		DeviceName:  stringPtr(truncate(req.DeviceName, 255)),
+		Platform:    stringPtr(truncate(req.Platform, 50)),
+		AppVersion:  stringPtr(truncate(req.AppVersion, maxAppVersionLength)),
+		IPAddress:   stringPtr(utils.ClientIP(ctx)),
+		UserAgent:   stringPtr(truncate(utils.UserAgent(ctx), maxUserAgentLength)),
+	})
+	if err != nil {
+		log.Printf("❌ Failed to register session: %v", err)
+		return nil, err
+	}
+
+	log.Printf("✅ Session registered | SessionID: %s", session.ID)
+	return toSessionResponse(session, currentFamily(ctx)), nil
+}
+
+// ✅ ListSessions
+func (s *SessionService) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Listing sessions | Auth0ID: %s", auth0ID)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	sessions, err := s.Repo.ListSessions(user.ID, req.IncludeRevoked)
+	if err != nil {
+		log.Printf("❌ Failed to list sessions: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListSessionsResponse{}
+	family := currentFamily(ctx)
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, toSessionResponse(session, family))
+	}
+
+	log.Printf("✅ Listed %d sessions", len(resp.Sessions))
+	return resp, nil
+}
+
+// ✅ RevokeSession
+func (s *SessionService) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionsResponse, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if uuid.Validate(req.SessionId) != nil {
+		return nil, status.Error(codes.InvalidArgument, "session_id must be a valid UUID")
+	}
+
+	log.Printf("🔹 Revoking session | Auth0ID: %s | SessionID: %s", auth0ID, req.SessionId)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	family, err := s.Repo.RevokeSession(user.ID, req.SessionId)
+	if err != nil {
+		log.Printf("❌ Failed to revoke session: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "active session not found")
+		}
+		return nil, err
+	}
+	s.markRevoked(auth0ID, family)
+	if err := s.Audit.Record(ctx, models.AuditSessionRevoked, auth0ID, map[string]string{"session_id": req.SessionId}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Session revoked | SessionID: %s", req.SessionId)
+	return &pb.RevokeSessionsResponse{Revoked: 1}, nil
+}
+
+// ✅ RevokeAllSessions
+func (s *SessionService) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeSessionsResponse, error) {
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Revoking all sessions | Auth0ID: %s | KeepCurrent: %t", auth0ID, req.KeepCurrent)
+
+	user, err := s.UserRepo.GetUser(auth0ID)
+	if err != nil {
+		log.Printf("❌ Failed to retrieve user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	keep := ""
+	if req.KeepCurrent {
+		if keep = currentFamily(ctx); keep == "" {
+			return nil, status.Error(codes.FailedPrecondition, "keep_current requires a token with a session ID")
+		}
+	}
+
+	families, err := s.Repo.RevokeAllSessions(user.ID, keep)
+	if err != nil {
+		log.Printf("❌ Failed to revoke sessions: %v", err)
+		return nil, err
+	}
+	s.markRevoked(auth0ID, families...)
+	if err := s.Audit.Record(ctx, models.AuditSessionRevoked, auth0ID, map[string]string{"revoked": strconv.Itoa(len(families))}); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Revoked %d token families | Auth0ID: %s", len(families), auth0ID)
+	return &pb.RevokeSessionsResponse{Revoked: int32(len(families))}, nil
+}
+
+// IsRevoked implements interceptors.RevocationChecker: tokens whose session ("sid") was revoked by
+// their own user are rejected.
+func (s *SessionService) IsRevoked(ctx context.Context, claims *auth.Claims) (bool, error) {
+	if claims.SessionID == "" {
+		return false, nil
+	}
+
+	revoked, err := s.revoked.get()
+	if err != nil {
+		return false, err
+	}
+	_, found := revoked[repositories.RevokedFamily{Auth0ID: claims.Subject, Family: claims.SessionID}]
+	return found, nil
+}
+
+// loadRevocations loads the families revoked within the lookback.
+func (s *SessionService) loadRevocations() (map[repositories.RevokedFamily]struct{}, error) {
+	families, err := s.Repo.ListRevokedFamilies(time.Now().Add(-s.Lookback).UTC())
+	if err != nil {
+		return nil, err
+	}
+	revoked := make(map[repositories.RevokedFamily]struct{}, len(families))
+	for _, family := range families {
+		revoked[family] = struct{}{}
+	}
+	return revoked, nil
+}
+
+// markRevoked applies local revocations immediately instead of waiting for the next reload.
+func (s *SessionService) markRevoked(auth0ID string, families ...string) {
+	s.revoked.update(func(current map[repositories.RevokedFamily]struct{}) map[repositories.RevokedFamily]struct{} {
+		revoked := maps.Clone(current)
+		if revoked == nil {
+			revoked = make(map[repositories.RevokedFamily]struct{})
+		}
+		for _, family := range families {
+			if family != "" {
+				revoked[repositories.RevokedFamily{Auth0ID: auth0ID, Family: family}] = struct{}{}
+			}
+		}
+		return revoked
+	})
+}
+
+// currentFamily returns the token family of the calling token, if any.
+func currentFamily(ctx context.Context) string {
+	if claims := auth.FromContext(ctx); claims != nil {
+		return claims.SessionID
+	}
+	return ""
+}
+
+// toSessionResponse converts a session model into its protobuf representation.
+func toSessionResponse(session *models.Session, currentFamily string) *pb.Session {
+	return &pb.Session{
+		Id:         session.ID,
+		UserId:     session.UserID,
+		DeviceId:   session.DeviceID,
+		DeviceName: derefString(session.DeviceName),
+		Platform:   derefString(session.Platform),
+		AppVersion: derefString(session.AppVersion),
+		IpAddress:  derefString(session.IPAddress),
+		UserAgent:  derefString(session.UserAgent),
+		CreatedAt:  utils.ToProtoTimestamp(session.CreatedAt),
+		LastSeenAt: utils.ToProtoTimestamp(session.LastSeenAt),
+		RevokedAt:  utils.ToOptionalProtoTimestamp(session.RevokedAt),
+		Current:    currentFamily != "" && derefString(session.TokenFamily) == currentFamily,
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestSessionCallsRejectBeforeLookup(t *testing.T) {
+	s := &SessionService{}
+	jane := callerContext("auth0|jane")
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantCode codes.Code
+	}{
+		{"register unauthenticated", func() error {
+			_, err := s.RegisterSession(context.Background(), &pb.RegisterSessionRequest{DeviceId: "iphone"})
+			return err
+		}, codes.Unauthenticated},
+		{"register for another user", func() error {
+			_, err := s.RegisterSession(jane, &pb.RegisterSessionRequest{Auth0Id: "auth0|john", DeviceId: "iphone"})
+			return err
+		}, codes.PermissionDenied},
+		{"register without a device", func() error {
+			_, err := s.RegisterSession(jane, &pb.RegisterSessionRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"list for another user", func() error {
+			_, err := s.ListSessions(jane, &pb.ListSessionsRequest{Auth0Id: "auth0|john"})
+			return err
+		}, codes.PermissionDenied},
+		{"revoke unauthenticated", func() error {
+			_, err := s.RevokeSession(context.Background(), &pb.RevokeSessionRequest{SessionId: "0b0c4c52-2f53-4b8e-9d1c-5d2f1a3e4b6c"})
+			return err
+		}, codes.Unauthenticated},
+		{"revoke with a malformed session id", func() error {
+			_, err := s.RevokeSession(jane, &pb.RevokeSessionRequest{SessionId: "42"})
+			return err
+		}, codes.InvalidArgument},
+		{"revoke all for another user", func() error {
+			_, err := s.RevokeAllSessions(jane, &pb.RevokeAllSessionsRequest{Auth0Id: "auth0|john"})
+			return err
+		}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestIsRevokedSkipsTokensWithoutSession(t *testing.T) {
+	s := &SessionService{}
+	revoked, err := s.IsRevoked(context.Background(), &auth.Claims{Subject: "auth0|jane"})
+	if err != nil || revoked {
+		t.Errorf("IsRevoked() = %v, %v, want false without a lookup", revoked, err)
+	}
+}
+
+func TestToSessionResponseMarksCurrent(t *testing.T) {
+	session := &models.Session{ID: "s1", DeviceID: "iphone", TokenFamily: stringPtr("sid-1")}
+
+	tests := []struct {
+		name    string
+		current string
+		want    bool
+	}{
+		{"same sid", "sid-1", true},
+		{"other sid", "sid-2", false},
+		{"caller without a sid", "", false},
+	}
+	for _, tt := range tests {
+		if got := toSessionResponse(session, tt.current).Current; got != tt.want {
+			t.Errorf("%s: Current = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := toSessionResponse(&models.Session{ID: "s2"}, "").Current; got {
+		t.Error("a session without a sid is never current")
+	}
+}
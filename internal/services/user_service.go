@@ -2,23 +2,48 @@ package services
 
 import (
 	"context"
-	"errors"
 	"log"
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
 	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
 	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
 )
 
 type UserService struct {
-	Repo *repositories.UserRepository
+	Repo           *repositories.UserRepository
+	UsernameRepo   *repositories.UsernameRepository
+	LoginRepo      *repositories.LoginRepository
+	Policy         *UsernamePolicy
+	ReservationTTL time.Duration
+	ChangeCooldown time.Duration
+	HoldPeriod     time.Duration
+	BatchLimit     int
+
+	ImportBatchSize int
 }
 
 // NewUserService creates a new UserService instance.
-func NewUserService(repo *repositories.UserRepository) *UserService {
-	return &UserService{Repo: repo}
+func NewUserService(repo *repositories.UserRepository, usernameRepo *repositories.UsernameRepository, loginRepo *repositories.LoginRepository, cfg *config.Config) *UserService {
+	return &UserService{
+		Repo:           repo,
+		UsernameRepo:   usernameRepo,
+		LoginRepo:      loginRepo,
+		Policy:         NewUsernamePolicy(cfg.ReservedUsernames, cfg.BlockedUsernameTerms),
+		ReservationTTL: cfg.UsernameReservationTTL,
+		ChangeCooldown: cfg.UsernameChangeCooldown,
+		HoldPeriod:     cfg.UsernameHoldPeriod,
+		BatchLimit:     cfg.BatchGetUsersLimit,
+
+		ImportBatchSize: cfg.ImportBatchSize,
+	}
 }
 
 // Helper functions
@@ -36,147 +61,234 @@ func derefString(s *string) string {
 	return *s
 }
 
+// toUserResponse converts a user model into its protobuf representation.
+func toUserResponse(user *models.User) *pb.UserResponse {
+	return &pb.UserResponse{
+		Id:       user.ID,
+		Auth0Id:  user.Auth0ID,
+		Email:    user.Email,
+		Username: derefString(user.Username),
+
+		CreatedAtRfc3339: utils.FormatTimestamp(user.CreatedAt),
+		DeletedAtRfc3339: utils.FormatOptionalTimestamp(user.DeletedAt),
+		CreatedAt:        utils.ToProtoTimestamp(user.CreatedAt),
+		UpdatedAt:        utils.ToProtoTimestamp(user.UpdatedAt),
+		LastLoginAt:      utils.ToOptionalProtoTimestamp(user.LastLoginAt),
+		DeletedAt:        utils.ToOptionalProtoTimestamp(user.DeletedAt),
+
+		DisplayName: derefString(user.DisplayName),
+		AvatarUrl:   derefString(user.AvatarURL),
+		Bio:         derefString(user.Bio),
+		Locale:      derefString(user.Locale),
+		Timezone:    derefString(user.Timezone),
+		DateOfBirth: formatDate(user.DateOfBirth),
+
+		Roles:           user.Roles,
+		Status:          accountStatuses[user.Status],
+		StatusReason:    derefString(user.StatusReason),
+		StatusExpiresAt: utils.ToOptionalProtoTimestamp(user.StatusExpiresAt),
+	}
+}
+
+// userResponseFor converts a user for the caller. Only the user themselves and callers with
+// read:user_emails see the email address, date of birth, last login, roles and the reason and expiry
+// of a restriction; everyone else gets the public profile.
+func userResponseFor(ctx context.Context, user *models.User) *pb.UserResponse {
+	resp := toUserResponse(user)
+	claims := auth.FromContext(ctx)
+	if claims != nil && (claims.Subject == user.Auth0ID || claims.HasPermission(auth.PermissionReadUserEmails)) {
+		return resp
+	}
+	resp.Email = ""
+	resp.DateOfBirth = ""
+	resp.LastLoginAt = nil
+	resp.Roles = nil
+	resp.StatusReason = ""
+	resp.StatusExpiresAt = nil
+	return resp
+}
+
+// formatDate formats a nullable calendar date as YYYY-MM-DD, returning "" when unset.
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
 // ✅ CreateUser - Prevent duplicate creation
 func (s *UserService) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.UserResponse, error) {
-	log.Printf("🔹 Checking if user exists | Auth0ID: %s", req.Auth0Id)
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🔹 Checking if user exists | Auth0ID: %s", auth0ID)
 
-	existingUser, err := s.Repo.GetUser(req.Auth0Id)
+	existingUser, err := s.Repo.GetUser(auth0ID)
 	if err == nil && existingUser != nil {
-		log.Printf("✅ User already exists, skipping creation | Auth0ID: %s", req.Auth0Id)
-		return &pb.UserResponse{
-			Id:        existingUser.ID,
-			Auth0Id:   existingUser.Auth0ID,
-			Email:     existingUser.Email,
-			Username:  derefString(existingUser.Username),
-			CreatedAt: existingUser.CreatedAt.Format(time.RFC3339),
-		}, nil
+		log.Printf("✅ User already exists, skipping creation | Auth0ID: %s", auth0ID)
+		return userResponseFor(ctx, existingUser), nil
 	}
 
-	log.Printf("🔹 Creating new user | Auth0ID: %s | Email: %s", req.Auth0Id, req.Email)
+	email := utils.NormalizeEmail(req.Email)
+	username := utils.NormalizeUsername(req.Username)
+
+	log.Printf("🔹 Creating new user | Auth0ID: %s", auth0ID)
+
+	if username != "" {
+		if err := s.checkUsernameClaim(auth0ID, username); err != nil {
+			log.Printf("❌ CreateUser: Username rejected: %v", err)
+			return nil, err
+		}
+	}
 
 	user := &models.User{
 		ID:        uuid.NewString(),
-		Auth0ID:   req.Auth0Id,
-		Email:     req.Email,
-		Username:  stringPtr(req.Username),
-		CreatedAt: time.Now(),
+		Auth0ID:   auth0ID,
+		Email:     email,
+		Username:  stringPtr(username),
+		CreatedAt: time.Now().UTC(),
 	}
 
-	err = s.Repo.CreateUser(user)
+	err = s.Repo.CreateUser(user, auditContext(ctx))
 	if err != nil {
 		log.Printf("❌ Failed to create user: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	log.Printf("✅ User created successfully: %s", user.ID)
 
-	return &pb.UserResponse{
-		Id:        user.ID,
-		Auth0Id:   user.Auth0ID,
-		Email:     user.Email,
-		Username:  derefString(user.Username),
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	if username != "" {
+		s.releaseUsernameReservation(user.Auth0ID, username)
+	}
+
+	return toUserResponse(user), nil
 }
 
-// ✅ GetUser
+// ✅ GetUser - Looks a user up by whichever selector is set on the request
 func (s *UserService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
-	log.Printf("🔹 Retrieving user | Auth0ID: %s", req.Auth0Id)
+	var user *models.User
+	var err error
+
+	switch selector := req.Selector.(type) {
+	case *pb.GetUserRequest_Auth0Id:
+		log.Printf("🔹 Retrieving user | Auth0ID: %s", selector.Auth0Id)
+		user, err = s.Repo.GetUser(selector.Auth0Id)
+	case *pb.GetUserRequest_Id:
+		log.Printf("🔹 Retrieving user | ID: %s", selector.Id)
+		if uuid.Validate(selector.Id) != nil {
+			return nil, status.Error(codes.InvalidArgument, "id must be a valid UUID")
+		}
+		user, err = s.Repo.GetUserByID(selector.Id)
+	case *pb.GetUserRequest_Email:
+		log.Println("🔹 Retrieving user by email")
+		if !auth.FromContext(ctx).HasPermission(auth.PermissionReadUserEmails) {
+			log.Println("❌ GetUser: Email lookup denied for unprivileged caller")
+			return nil, status.Error(codes.PermissionDenied, "email lookups require the read:user_emails permission")
+		}
+		user, err = s.Repo.GetUserByEmail(utils.NormalizeEmail(selector.Email))
+	case *pb.GetUserRequest_Username:
+		log.Println("🔹 Retrieving user by username")
+		user, err = s.resolveUsername(utils.NormalizeUsername(selector.Username))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "one of id, auth0_id, email or username is required")
+	}
 
-	user, err := s.Repo.GetUser(req.Auth0Id)
 	if err != nil {
 		log.Printf("❌ Failed to retrieve user: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	log.Printf("✅ User retrieved successfully: %s", user.Auth0ID)
 
-	return &pb.UserResponse{
-		Id:        user.ID,
-		Auth0Id:   user.Auth0ID,
-		Email:     user.Email,
-		Username:  derefString(user.Username),
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return userResponseFor(ctx, user), nil
 }
 
 // ✅ UpdateUsername
 func (s *UserService) UpdateUsername(ctx context.Context, req *pb.UpdateUsernameRequest) (*pb.UserResponse, error) {
-	log.Printf("🔹 Updating username | Auth0ID: %s | New Username: %s", req.Auth0Id, req.Username)
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+	username := utils.NormalizeUsername(req.Username)
+	log.Printf("🔹 Updating username | Auth0ID: %s", auth0ID)
 
-	if req.Username == "" {
+	if username == "" {
 		log.Println("❌ UpdateUsername: Username is empty")
-		return nil, errors.New("username is required")
+		return nil, status.Error(codes.InvalidArgument, "username is required")
 	}
 
-	err := s.Repo.UpdateUsername(req.Auth0Id, req.Username)
+	if err := s.checkUsernameClaim(auth0ID, username); err != nil {
+		log.Printf("❌ UpdateUsername: Username rejected: %v", err)
+		return nil, err
+	}
+
+	err = s.Repo.UpdateUsername(auth0ID, username, s.ChangeCooldown, auditContext(ctx))
 	if err != nil {
 		log.Printf("❌ Failed to update username in DB: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
 	log.Println("✅ Username updated successfully in DB")
+	s.releaseUsernameReservation(auth0ID, username)
 
-	user, err := s.Repo.GetUser(req.Auth0Id)
+	user, err := s.Repo.GetUser(auth0ID)
 	if err != nil {
 		log.Printf("❌ Failed to retrieve updated user: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	log.Printf("✅ Username update confirmed | Auth0ID: %s | Username: %s", user.Auth0ID, derefString(user.Username))
+	log.Printf("✅ Username update confirmed | Auth0ID: %s", user.Auth0ID)
 
-	return &pb.UserResponse{
-		Id:        user.ID,
-		Auth0Id:   user.Auth0ID,
-		Email:     user.Email,
-		Username:  derefString(user.Username),
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return toUserResponse(user), nil
 }
 
-// ✅ UpdateUser (Email)
+// ✅ UpdateUser (Email and profile fields)
 func (s *UserService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserResponse, error) {
-	log.Printf("🔹 Updating user email | Auth0ID: %s | New Email: %s", req.Auth0Id, req.Email)
-
-	if req.Email == "" {
-		log.Println("❌ UpdateUser: Email is empty")
-		return nil, errors.New("email is required")
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
 	}
+	log.Printf("🔹 Updating user | Auth0ID: %s", auth0ID)
 
-	err := s.Repo.UpdateUserEmail(req.Auth0Id, req.Email)
+	update, err := profileUpdateFromRequest(req)
 	if err != nil {
-		log.Printf("❌ Failed to update email: %v", err)
+		log.Printf("❌ UpdateUser: %v", err)
 		return nil, err
 	}
 
-	log.Println("✅ Email updated successfully")
+	err = s.Repo.UpdateProfile(auth0ID, update, auditContext(ctx))
+	if err != nil {
+		log.Printf("❌ Failed to update user: %v", err)
+		return nil, toStatusError(err)
+	}
+
+	log.Println("✅ User updated successfully")
 
-	user, err := s.Repo.GetUser(req.Auth0Id)
+	user, err := s.Repo.GetUser(auth0ID)
 	if err != nil {
 		log.Printf("❌ Failed to retrieve updated user: %v", err)
-		return nil, err
+		return nil, toStatusError(err)
 	}
 
-	return &pb.UserResponse{
-		Id:        user.ID,
-		Auth0Id:   user.Auth0ID,
-		Email:     user.Email,
-		Username:  derefString(user.Username),
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-	}, nil
+	return toUserResponse(user), nil
 }
 
 // ✅ DeleteUser
 func (s *UserService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.DeleteUserResponse, error) {
-	log.Printf("🔹 Deleting user | Auth0ID: %s", req.Auth0Id)
+	auth0ID, err := resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🔹 Deleting user | Auth0ID: %s", auth0ID)
 
-	err := s.Repo.DeleteUser(req.Auth0Id)
+	err = s.Repo.DeleteUser(auth0ID, auditContext(ctx))
 	if err != nil {
 		log.Printf("❌ Failed to delete user: %v", err)
 		return nil, err
 	}
 
-	log.Printf("✅ User deleted successfully: %s", req.Auth0Id)
+	log.Printf("✅ User deleted successfully: %s", auth0ID)
 
 	return &pb.DeleteUserResponse{
 		Message: "User deleted successfully",
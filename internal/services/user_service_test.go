@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestUpdateUsernameRejectsInvalidUsernames(t *testing.T) {
+	s := &UserService{Policy: NewUsernamePolicy([]string{"admin"}, nil)}
+
+	tests := []struct {
+		name     string
+		username string
+	}{
+		{"empty", ""},
+		{"only whitespace", "   "},
+		{"too short", "jd"},
+		{"invalid characters", "jane doe"},
+		{"reserved", "Admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.UpdateUsernameRequest{Auth0Id: "auth0|jane", Username: tt.username}
+			if _, err := s.UpdateUsername(callerContext("auth0|jane"), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("UpdateUsername(%q) error = %v, want InvalidArgument", tt.username, err)
+			}
+		})
+	}
+}
+
+func TestGetUserRejectsBeforeLookup(t *testing.T) {
+	s := &UserService{}
+	reader := callerContext("auth0|jane", auth.PermissionReadUserEmails)
+	caller := callerContext("auth0|jane")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *pb.GetUserRequest
+		wantCode codes.Code
+	}{
+		{"no selector", reader, &pb.GetUserRequest{}, codes.InvalidArgument},
+		{"id not a uuid", reader, &pb.GetUserRequest{Selector: &pb.GetUserRequest_Id{Id: "42"}}, codes.InvalidArgument},
+		{"email unauthenticated", context.Background(), &pb.GetUserRequest{Selector: &pb.GetUserRequest_Email{Email: "jane@example.com"}}, codes.PermissionDenied},
+		{"email without permission", caller, &pb.GetUserRequest{Selector: &pb.GetUserRequest_Email{Email: "jane@example.com"}}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.GetUser(tt.ctx, tt.req); status.Code(err) != tt.wantCode {
+				t.Errorf("GetUser() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestUserMutationsRejectOtherCallers(t *testing.T) {
+	s := &UserService{}
+	jane := callerContext("auth0|jane", auth.PermissionReadUserEmails)
+
+	calls := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"CreateUser", func(ctx context.Context) error {
+			_, err := s.CreateUser(ctx, &pb.CreateUserRequest{Auth0Id: "auth0|john", Email: "john@example.com"})
+			return err
+		}},
+		{"UpdateUser", func(ctx context.Context) error {
+			_, err := s.UpdateUser(ctx, &pb.UpdateUserRequest{Auth0Id: "auth0|john", Email: "john@example.com"})
+			return err
+		}},
+		{"UpdateUsername", func(ctx context.Context) error {
+			_, err := s.UpdateUsername(ctx, &pb.UpdateUsernameRequest{Auth0Id: "auth0|john", Username: "john_doe"})
+			return err
+		}},
+		{"DeleteUser", func(ctx context.Context) error {
+			_, err := s.DeleteUser(ctx, &pb.DeleteUserRequest{Auth0Id: "auth0|john"})
+			return err
+		}},
+	}
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.call(context.Background()); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("%s() without a token error = %v, want Unauthenticated", c.name, err)
+			}
+			if err := c.call(jane); status.Code(err) != codes.PermissionDenied {
+				t.Errorf("%s() for another user error = %v, want PermissionDenied", c.name, err)
+			}
+		})
+	}
+}
+
+func TestUserResponseFor(t *testing.T) {
+	dob := time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)
+	lastLogin := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	suspendedUntil := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	user := &models.User{
+		Auth0ID:     "auth0|jane",
+		Email:       "jane@example.com",
+		Username:    stringPtr("jane_doe"),
+		DateOfBirth: &dob,
+		LastLoginAt: &lastLogin,
+		Roles:       []string{"support"},
+
+		Status:          models.UserStatusSuspended,
+		StatusReason:    stringPtr("spam"),
+		StatusExpiresAt: &suspendedUntil,
+	}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		wantPrivate bool
+	}{
+		{"unauthenticated", context.Background(), false},
+		{"another user", callerContext("auth0|john"), false},
+		{"the user themselves", callerContext("auth0|jane"), true},
+		{"with read:user_emails", callerContext("auth0|support", auth.PermissionReadUserEmails), true},
+		{"admin", callerContext("auth0|admin", auth.PermissionAdmin), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := userResponseFor(tt.ctx, user)
+			if resp.Username != "jane_doe" || resp.Status != pb.AccountStatus_ACCOUNT_STATUS_SUSPENDED {
+				t.Errorf("Username = %q, Status = %v, want the public profile", resp.Username, resp.Status)
+			}
+			hasPrivate := resp.Email != "" || resp.DateOfBirth != "" || resp.LastLoginAt != nil || resp.Roles != nil ||
+				resp.StatusReason != "" || resp.StatusExpiresAt != nil
+			hasAll := resp.Email != "" && resp.DateOfBirth != "" && resp.LastLoginAt != nil && resp.Roles != nil &&
+				resp.StatusReason != "" && resp.StatusExpiresAt != nil
+			if tt.wantPrivate && !hasAll || !tt.wantPrivate && hasPrivate {
+				t.Errorf("userResponseFor() = %+v, want private fields: %v", resp, tt.wantPrivate)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+const maxUsernameSuggestions = 3
+
+var (
+	errUsernameReserved = errors.New("username is reserved")
+	errUsernameBlocked  = errors.New("username contains a blocked term")
+	errUsernameOnHold   = errors.New("username was recently released and is on hold")
+)
+
+// UsernamePolicy holds the reserved words and blocked terms that usernames are checked against.
+type UsernamePolicy struct {
+	reserved map[string]struct{}
+	blocked  []string
+}
+
+// NewUsernamePolicy creates a UsernamePolicy. Reserved words must match exactly
+// (case-insensitive); blocked terms are rejected anywhere inside a username.
+func NewUsernamePolicy(reserved, blocked []string) *UsernamePolicy {
+	policy := &UsernamePolicy{reserved: make(map[string]struct{}, len(reserved))}
+	for _, word := range reserved {
+		policy.reserved[utils.UsernameKey(word)] = struct{}{}
+	}
+	for _, term := range blocked {
+		if term = utils.UsernameKey(term); term != "" {
+			policy.blocked = append(policy.blocked, term)
+		}
+	}
+	return policy
+}
+
+// Validate applies the utils.ValidateUsername rules plus the reserved and blocked lists.
+func (p *UsernamePolicy) Validate(username string) error {
+	if err := utils.ValidateUsername(username); err != nil {
+		return err
+	}
+
+	key := utils.UsernameKey(username)
+	if _, ok := p.reserved[key]; ok {
+		return errUsernameReserved
+	}
+
+	compact := strings.ReplaceAll(key, "_", "")
+	for _, term := range p.blocked {
+		if strings.Contains(key, term) || strings.Contains(compact, term) {
+			return errUsernameBlocked
+		}
+	}
+	return nil
+}
+
+// ✅ CheckUsernameAvailability
+func (s *UserService) CheckUsernameAvailability(ctx context.Context, req *pb.CheckUsernameAvailabilityRequest) (*pb.CheckUsernameAvailabilityResponse, error) {
+	username := utils.NormalizeUsername(req.Username)
+	log.Printf("🔹 Checking username availability | Reserve: %t", req.Reserve)
+
+	auth0ID := req.Auth0Id
+	if req.Reserve {
+		// Reservations act on an account, so they need a token like other self-service calls;
+		// this also keeps restricted users from reserving names by leaving the token out.
+		var err error
+		if auth0ID, err = resolveSubject(ctx, req.Auth0Id, auth.PermissionAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pb.CheckUsernameAvailabilityResponse{Username: username}
+
+	if err := s.Policy.Validate(username); err != nil {
+		resp.Reason = err.Error()
+		if errors.Is(err, errUsernameReserved) {
+			resp.Suggestions = s.suggestUsernames(username)
+		}
+		return resp, nil
+	}
+
+	reason, err := s.usernameUnavailableReason(auth0ID, username)
+	if err != nil {
+		log.Printf("❌ Failed to check username availability: %v", err)
+		return nil, err
+	}
+	if reason != "" {
+		resp.Reason = reason
+		resp.Suggestions = s.suggestUsernames(username)
+		return resp, nil
+	}
+
+	if req.Reserve {
+		expiresAt := time.Now().Add(s.ReservationTTL).UTC()
+		reserved, err := s.UsernameRepo.ReserveUsername(utils.UsernameKey(username), username, auth0ID, expiresAt)
+		if err != nil {
+			log.Printf("❌ Failed to reserve username: %v", err)
+			return nil, err
+		}
+		if !reserved {
+			// Lost the race to another signup between the check and the insert.
+			resp.Reason = errUsernameReserved.Error()
+			resp.Suggestions = s.suggestUsernames(username)
+			return resp, nil
+		}
+		resp.ReservedUntil = utils.FormatTimestamp(expiresAt)
+		log.Printf("✅ Username reserved | Auth0ID: %s", auth0ID)
+	}
+
+	resp.Available = true
+	return resp, nil
+}
+
+// usernameUnavailableReason reports why the caller can't claim a policy-valid
+// username right now, or "" if it is free (or already theirs).
+func (s *UserService) usernameUnavailableReason(auth0ID, username string) (string, error) {
+	owner, err := s.UsernameRepo.GetUsernameOwner(username)
+	if err != nil {
+		return "", err
+	}
+	if owner != "" && owner != auth0ID {
+		return "username is already taken", nil
+	}
+
+	holder, err := s.UsernameRepo.GetReservationHolder(utils.UsernameKey(username))
+	if err != nil {
+		return "", err
+	}
+	if holder != "" && holder != auth0ID {
+		return errUsernameReserved.Error(), nil
+	}
+
+	if owner == "" {
+		previous, err := s.UsernameRepo.GetRetiredUsernameOwner(username, s.holdSince())
+		if err != nil {
+			return "", err
+		}
+		if previous != "" && previous != auth0ID {
+			return errUsernameOnHold.Error(), nil
+		}
+	}
+	return "", nil
+}
+
+// checkUsernameClaim validates a username the account is about to take, returning a gRPC status error.
+func (s *UserService) checkUsernameClaim(auth0ID, username string) error {
+	if err := s.Policy.Validate(username); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	holder, err := s.UsernameRepo.GetReservationHolder(utils.UsernameKey(username))
+	if err != nil {
+		return err
+	}
+	if holder != "" && holder != auth0ID {
+		return status.Error(codes.AlreadyExists, errUsernameReserved.Error())
+	}
+
+	previous, err := s.UsernameRepo.GetRetiredUsernameOwner(username, s.holdSince())
+	if err != nil {
+		return err
+	}
+	if previous != "" && previous != auth0ID {
+		return status.Error(codes.AlreadyExists, errUsernameOnHold.Error())
+	}
+	return nil
+}
+
+// holdSince is the cutoff before which retired usernames are free for other accounts to claim.
+func (s *UserService) holdSince() time.Time {
+	return time.Now().Add(-s.HoldPeriod).UTC()
+}
+
+// resolveUsername finds the account currently using the username, falling back to the
+// account that retired it within the hold period so old profile links keep working.
+func (s *UserService) resolveUsername(username string) (*models.User, error) {
+	owner, err := s.UsernameRepo.GetUsernameOwner(username)
+	if err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		owner, err = s.UsernameRepo.GetRetiredUsernameOwner(username, s.holdSince())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if owner == "" {
+		return nil, sql.ErrNoRows
+	}
+	return s.Repo.GetUser(owner)
+}
+
+// releaseUsernameReservation clears the account's hold once the username has been claimed.
+func (s *UserService) releaseUsernameReservation(auth0ID, username string) {
+	if err := s.UsernameRepo.ReleaseReservations(utils.UsernameKey(username), auth0ID); err != nil {
+		log.Printf("❌ Failed to release username reservation: %v", err)
+	}
+}
+
+// suggestUsernames returns up to maxUsernameSuggestions available variations of the username.
+func (s *UserService) suggestUsernames(username string) []string {
+	base := username
+	if len(base) > 44 {
+		base = base[:44]
+	}
+
+	candidates := []string{base + "_music", base + "_band", base + "_"}
+	for i := 0; i < 5; i++ {
+		candidates = append(candidates, fmt.Sprintf("%s%d", base, rand.IntN(9000)+100))
+	}
+
+	var valid, keys []string
+	for _, candidate := range candidates {
+		if s.Policy.Validate(candidate) == nil {
+			valid = append(valid, candidate)
+			keys = append(keys, utils.UsernameKey(candidate))
+		}
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+
+	unavailable, err := s.UsernameRepo.FindUnavailableUsernames(keys, s.holdSince())
+	if err != nil {
+		log.Printf("❌ Failed to check username suggestions: %v", err)
+		return nil
+	}
+
+	var suggestions []string
+	for i, candidate := range valid {
+		if !unavailable[keys[i]] {
+			suggestions = append(suggestions, candidate)
+			if len(suggestions) == maxUsernameSuggestions {
+				break
+			}
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestUsernamePolicyValidate(t *testing.T) {
+	policy := NewUsernamePolicy([]string{"Admin", "support"}, []string{"spam", " "})
+
+	tests := []struct {
+		name     string
+		username string
+		want     error
+		wantErr  bool
+	}{
+		{name: "allowed", username: "jane_doe"},
+		{name: "reserved", username: "admin", want: errUsernameReserved},
+		{name: "reserved in another case", username: "SUPPORT", want: errUsernameReserved},
+		{name: "reserved word inside a longer name", username: "admin_jane"},
+		{name: "blocked term", username: "spamking", want: errUsernameBlocked},
+		{name: "blocked term split by underscores", username: "s_p_a_m_king", want: errUsernameBlocked},
+		{name: "too short", username: "jd", wantErr: true},
+		{name: "invalid characters", username: "jane.doe", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.username)
+			switch {
+			case tt.want != nil:
+				if !errors.Is(err, tt.want) {
+					t.Errorf("Validate(%q) = %v, want %v", tt.username, err, tt.want)
+				}
+			case (err != nil) != tt.wantErr:
+				t.Errorf("Validate(%q) = %v, want error: %v", tt.username, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckUsernameAvailabilityRejectsBeforeLookup(t *testing.T) {
+	s := &UserService{Policy: NewUsernamePolicy(nil, nil)}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *pb.CheckUsernameAvailabilityRequest
+		wantCode   codes.Code
+		wantReason bool
+	}{
+		{
+			name:     "reserve unauthenticated",
+			req:      &pb.CheckUsernameAvailabilityRequest{Username: "jane_doe", Auth0Id: "auth0|jane", Reserve: true},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "reserve for another user",
+			ctx:      callerContext("auth0|john"),
+			req:      &pb.CheckUsernameAvailabilityRequest{Username: "jane_doe", Auth0Id: "auth0|jane", Reserve: true},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:       "invalid username",
+			req:        &pb.CheckUsernameAvailabilityRequest{Username: "j"},
+			wantReason: true,
+		},
+		{
+			name:       "invalid username after normalization",
+			req:        &pb.CheckUsernameAvailabilityRequest{Username: "  ｊａｎｅ.doe "},
+			wantReason: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			resp, err := s.CheckUsernameAvailability(ctx, tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("CheckUsernameAvailability() error = %v, want %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Available || (resp.Reason != "") != tt.wantReason {
+				t.Errorf("CheckUsernameAvailability() = available %v, reason %q", resp.Available, resp.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+var errInvalidResumeToken = status.Error(codes.InvalidArgument, "invalid resume_token")
+
+// eventTypes maps outbox event types to their protobuf representation.
+var eventTypes = map[string]pb.UserEventType{
+	models.EventUserCreated: pb.UserEventType_USER_EVENT_TYPE_CREATED,
+	models.EventUserUpdated: pb.UserEventType_USER_EVENT_TYPE_UPDATED,
+	models.EventUserDeleted: pb.UserEventType_USER_EVENT_TYPE_DELETED,
+	models.EventUserErased:  pb.UserEventType_USER_EVENT_TYPE_ERASED,
+}
+
+// WatchService streams the user change feed from the outbox to WatchUsers subscribers.
+type WatchService struct {
+	Repo         *repositories.OutboxRepository
+	PollInterval time.Duration // Fallback when notifications are missed or unavailable
+	BatchSize    int
+	Retention    time.Duration // Matches the outbox retention; older resume tokens cannot be honored
+
+	mu   sync.Mutex
+	wake chan struct{}
+}
+
+// NewWatchService creates a new WatchService instance.
+func NewWatchService(repo *repositories.OutboxRepository, cfg *config.Config) *WatchService {
+	return &WatchService{
+		Repo:         repo,
+		PollInterval: cfg.WatchPollInterval,
+		BatchSize:    max(cfg.OutboxBatchSize, 1),
+		Retention:    cfg.OutboxRetention,
+		wake:         make(chan struct{}),
+	}
+}
+
+// RunNotifications wakes every watcher whenever the database announces new events, until ctx is cancelled.
+func (s *WatchService) RunNotifications(ctx context.Context, listener *pq.Listener) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-listener.Notify:
+			// A nil notification means the listener reconnected; waking up covers anything missed.
+			s.mu.Lock()
+			close(s.wake)
+			s.wake = make(chan struct{})
+			s.mu.Unlock()
+		}
+	}
+}
+
+// wakeup returns a channel closed on the next notification.
+func (s *WatchService) wakeup() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.wake
+}
+
+// ✅ WatchUsers - Streams committed user changes in order until the client goes away
+func (s *WatchService) WatchUsers(req *pb.WatchUsersRequest, stream grpc.ServerStreamingServer[pb.UserEvent]) error {
+	ctx := stream.Context()
+	if err := requirePermission(ctx, auth.PermissionWatchUsers); err != nil {
+		return err
+	}
+
+	position, err := s.startPosition(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[pb.UserEventType]bool, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		wanted[eventType] = true
+	}
+
+	log.Printf("🔹 Watching users | From: %d/%d", position.XactID, position.ID)
+
+	sent := 0
+	done := func() error {
+		log.Printf("✅ User watch ended | Events: %d", sent)
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	for {
+		wake := s.wakeup()
+		events, err := s.Repo.ListEventsAfter(ctx, position, s.BatchSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return done()
+			}
+			log.Printf("❌ Failed to read user change feed: %v", err)
+			return err
+		}
+
+		for _, event := range events {
+			position = repositories.OutboxPosition{XactID: event.XactID, ID: event.ID}
+			resp, err := toUserEventResponse(event, position)
+			if err != nil {
+				log.Printf("❌ Skipping undecodable event %d: %v", event.ID, err)
+				continue
+			}
+			if len(wanted) > 0 && !wanted[resp.Type] {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				log.Printf("❌ User watch stopped after %d events: %v", sent, err)
+				return err
+			}
+			sent++
+		}
+		if len(events) == s.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return done()
+		case <-wake:
+		case <-time.After(s.PollInterval):
+		}
+	}
+}
+
+// startPosition resolves where a watch begins: after the resume token, at the oldest retained
+// event, or at the current head.
+func (s *WatchService) startPosition(ctx context.Context, req *pb.WatchUsersRequest) (repositories.OutboxPosition, error) {
+	if req.ResumeToken != "" {
+		token, err := decodeResumeToken(req.ResumeToken)
+		if err != nil {
+			return repositories.OutboxPosition{}, err
+		}
+		if time.Since(token.OccurredAt) > s.Retention {
+			return repositories.OutboxPosition{}, status.Error(codes.OutOfRange, "resume_token is older than the event retention period; reload and watch from the current head")
+		}
+		return repositories.OutboxPosition{XactID: token.XactID, ID: token.ID}, nil
+	}
+	if req.FromOldest {
+		return repositories.OutboxPosition{}, nil
+	}
+
+	position, err := s.Repo.CurrentPosition(ctx)
+	if err != nil {
+		log.Printf("❌ Failed to read change feed head: %v", err)
+		return position, err
+	}
+	return position, nil
+}
+
+// resumeToken is the decoded form of the opaque resume tokens attached to events.
+type resumeToken struct {
+	XactID     int64     `json:"x"`
+	ID         int64     `json:"i"`
+	OccurredAt time.Time `json:"t"`
+}
+
+func encodeResumeToken(token resumeToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeResumeToken(raw string) (*resumeToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errInvalidResumeToken
+	}
+	var token resumeToken
+	if err := json.Unmarshal(data, &token); err != nil || token.ID <= 0 {
+		return nil, errInvalidResumeToken
+	}
+	return &token, nil
+}
+
+// toUserEventResponse converts an outbox event into its protobuf representation.
+func toUserEventResponse(event *models.OutboxEvent, position repositories.OutboxPosition) (*pb.UserEvent, error) {
+	var payload models.UserEventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.UserEvent{
+		Id:            event.ID,
+		Type:          eventTypes[event.EventType],
+		ChangedFields: payload.ChangedFields,
+		OccurredAt:    utils.ToProtoTimestamp(event.CreatedAt),
+		ResumeToken:   encodeResumeToken(resumeToken{XactID: position.XactID, ID: position.ID, OccurredAt: event.CreatedAt}),
+	}
+	if payload.User != nil {
+		resp.User = toUserResponse(payload.User)
+	}
+	return resp, nil
+}
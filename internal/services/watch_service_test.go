@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+func TestResumeTokenRoundTrip(t *testing.T) {
+	token := resumeToken{XactID: 7812, ID: 42, OccurredAt: time.Date(2026, 6, 1, 8, 0, 0, 999, time.UTC)}
+
+	got, err := decodeResumeToken(encodeResumeToken(token))
+	if err != nil {
+		t.Fatalf("decodeResumeToken() = %v", err)
+	}
+	if got.XactID != token.XactID || got.ID != token.ID || !got.OccurredAt.Equal(token.OccurredAt) {
+		t.Errorf("decodeResumeToken() = %+v, want %+v", *got, token)
+	}
+}
+
+func TestDecodeResumeTokenRejectsInvalidTokens(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"not base64", "%%%"},
+		{"not json", encode("resume")},
+		{"missing id", encode(`{"x":1,"t":"2026-06-01T08:00:00Z"}`)},
+		{"negative id", encode(`{"x":1,"i":-3,"t":"2026-06-01T08:00:00Z"}`)},
+		{"malformed time", encode(`{"x":1,"i":3,"t":"yesterday"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeResumeToken(tt.raw); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("decodeResumeToken(%q) error = %v, want InvalidArgument", tt.raw, err)
+			}
+		})
+	}
+}
+
+func TestStartPositionFromResumeToken(t *testing.T) {
+	s := &WatchService{Retention: 24 * time.Hour}
+
+	tests := []struct {
+		name     string
+		req      *pb.WatchUsersRequest
+		want     repositories.OutboxPosition
+		wantCode codes.Code
+	}{
+		{
+			name: "recent token",
+			req:  &pb.WatchUsersRequest{ResumeToken: encodeResumeToken(resumeToken{XactID: 9, ID: 4, OccurredAt: time.Now().Add(-time.Hour)})},
+			want: repositories.OutboxPosition{XactID: 9, ID: 4},
+		},
+		{
+			name:     "token older than retention",
+			req:      &pb.WatchUsersRequest{ResumeToken: encodeResumeToken(resumeToken{XactID: 9, ID: 4, OccurredAt: time.Now().Add(-48 * time.Hour)})},
+			wantCode: codes.OutOfRange,
+		},
+		{
+			name:     "invalid token",
+			req:      &pb.WatchUsersRequest{ResumeToken: "garbage"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "from oldest",
+			req:  &pb.WatchUsersRequest{FromOldest: true},
+			want: repositories.OutboxPosition{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.startPosition(context.Background(), tt.req)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("startPosition() error = %v, want %v", err, tt.wantCode)
+			}
+			if got != tt.want {
+				t.Errorf("startPosition() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+)
+
+// Headers sent with every webhook request.
+const (
+	WebhookSignatureHeader = "X-BandRoom-Signature" // "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">"
+	WebhookEventHeader     = "X-BandRoom-Event"     // Event type, e.g. UserCreated
+	WebhookDeliveryHeader  = "X-BandRoom-Delivery"  // Delivery ID, stable across retries
+)
+
+// WebhookDispatchPolicy controls how deliveries are sent and retried.
+type WebhookDispatchPolicy struct {
+	MaxAttempts int           // Attempts before a delivery is dead-lettered
+	BackoffBase time.Duration // Delay after the first failure, doubled after each further one
+	BackoffMax  time.Duration // Upper bound on the delay between attempts
+	Timeout     time.Duration // Per-request timeout
+	BatchSize   int           // Deliveries claimed per round
+}
+
+// RunDispatcher sends due deliveries every interval until ctx is cancelled.
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	client := &http.Client{Timeout: s.Dispatch.Timeout}
+	runPeriodically(ctx, interval, func() {
+		for {
+			// Claimed deliveries are leased for longer than a full round of requests can take.
+			lease := s.Dispatch.Timeout*time.Duration(s.Dispatch.BatchSize) + time.Minute
+			due, err := s.Repo.ClaimDueDeliveries(s.Dispatch.BatchSize, lease)
+			if err != nil {
+				log.Printf("❌ Failed to claim webhook deliveries: %v", err)
+				return
+			}
+			for _, delivery := range due {
+				s.deliver(ctx, client, delivery)
+			}
+			if len(due) < s.Dispatch.BatchSize || ctx.Err() != nil {
+				return
+			}
+		}
+	})
+}
+
+// deliver makes one attempt and records its outcome.
+func (s *WebhookService) deliver(ctx context.Context, client *http.Client, delivery *repositories.DueWebhookDelivery) {
+	statusCode, err := sendWebhook(ctx, client, delivery)
+	if err == nil {
+		if err := s.Repo.MarkDelivered(delivery.ID, statusCode); err != nil {
+			log.Printf("❌ Failed to record webhook delivery %d: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	message := truncate(err.Error(), 500)
+	attempt := delivery.Attempts + 1
+
+	if attempt >= s.Dispatch.MaxAttempts {
+		log.Printf("❌ Webhook delivery %d dead-lettered after %d attempts: %s", delivery.ID, attempt, message)
+		err = s.Repo.MarkDead(delivery.ID, code, message)
+	} else {
+		err = s.Repo.ScheduleRetry(delivery.ID, code, message, time.Now().Add(s.backoff(attempt)).UTC())
+	}
+	if err != nil {
+		log.Printf("❌ Failed to record webhook attempt for delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// backoff returns the delay before the attempt after the given failed one: exponential, capped,
+// with up to 20% jitter so endpoints recovering from an outage are not hit all at once.
+func (s *WebhookService) backoff(failedAttempts int) time.Duration {
+	delay := s.Dispatch.BackoffBase
+	for i := 1; i < failedAttempts && delay < s.Dispatch.BackoffMax; i++ {
+		delay *= 2
+	}
+	delay = min(delay, s.Dispatch.BackoffMax)
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1))
+}
+
+// sendWebhook POSTs the signed payload. Any non-2xx response is an error.
+func sendWebhook(ctx context.Context, client *http.Client, delivery *repositories.DueWebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "BandRoom-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(delivery.Secret, time.Now(), delivery.Payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the signature header value for a request body sent at t. Receivers recompute
+// HMAC-SHA256(secret, "<t>.<body>"), compare it in constant time and reject stale timestamps.
+func SignWebhook(secret string, t time.Time, body []byte) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
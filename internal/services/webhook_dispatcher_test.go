@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+)
+
+func TestSignWebhook(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+	want := "t=1700000000,v1=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8"
+
+	tests := []struct {
+		name   string
+		secret string
+		at     time.Time
+		body   []byte
+		match  bool
+	}{
+		{"same inputs", "whsec_test", at, body, true},
+		{"sub-second time", "whsec_test", at.Add(900 * time.Millisecond), body, true},
+		{"other secret", "whsec_other", at, body, false},
+		{"other time", "whsec_test", at.Add(time.Second), body, false},
+		{"other body", "whsec_test", at, []byte(`{"id":2}`), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SignWebhook(tt.secret, tt.at, tt.body); (got == want) != tt.match {
+				t.Errorf("SignWebhook() = %q, match %q: %v", got, want, tt.match)
+			}
+		})
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	s := &WebhookService{Dispatch: WebhookDispatchPolicy{BackoffBase: time.Second, BackoffMax: time.Minute}}
+
+	tests := []struct {
+		failedAttempts int
+		want           time.Duration // Before jitter
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{6, 32 * time.Second},
+		{7, time.Minute},
+		{50, time.Minute},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			got := s.backoff(tt.failedAttempts)
+			if got < tt.want || got > tt.want+tt.want/5 {
+				t.Errorf("backoff(%d) = %v, want within 20%% above %v", tt.failedAttempts, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSendWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantStatus int
+		wantErr    bool
+	}{
+		{"ok", http.StatusOK, http.StatusOK, false},
+		{"accepted", http.StatusAccepted, http.StatusAccepted, false},
+		{"redirect", http.StatusFound, http.StatusFound, true},
+		{"server error", http.StatusServiceUnavailable, http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			delivery := &repositories.DueWebhookDelivery{
+				WebhookDelivery: models.WebhookDelivery{ID: 17, EventType: models.EventUserCreated, Payload: []byte(`{"id":1}`)},
+				URL:             server.URL,
+				Secret:          "whsec_test",
+			}
+			client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+			statusCode, err := sendWebhook(context.Background(), client, delivery)
+			if (err != nil) != tt.wantErr || statusCode != tt.wantStatus {
+				t.Fatalf("sendWebhook() = %d, %v, want %d, error: %v", statusCode, err, tt.wantStatus, tt.wantErr)
+			}
+			if got := received.Header.Get(WebhookDeliveryHeader); got != "17" {
+				t.Errorf("%s = %q, want 17", WebhookDeliveryHeader, got)
+			}
+			if got := received.Header.Get(WebhookEventHeader); got != models.EventUserCreated {
+				t.Errorf("%s = %q, want %s", WebhookEventHeader, got, models.EventUserCreated)
+			}
+			if string(receivedBody) != `{"id":1}` {
+				t.Errorf("body = %s, want the payload", receivedBody)
+			}
+			signature := received.Header.Get(WebhookSignatureHeader)
+			rawTimestamp, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+			timestamp, _ := strconv.ParseInt(rawTimestamp, 10, 64)
+			if want := SignWebhook("whsec_test", time.Unix(timestamp, 0), receivedBody); signature != want {
+				t.Errorf("%s = %q, want %q", WebhookSignatureHeader, signature, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/xIndustries/BandRoom/backend-auth/config"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/auth"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/models"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/repositories"
+	"github.com/xIndustries/BandRoom/backend-auth/internal/utils"
+	pb "github.com/xIndustries/BandRoom/backend-auth/proto/Generated"
+)
+
+const minWebhookSecretLength = 32
+
+var errWebhookSubscriptionNotFound = status.Error(codes.NotFound, "webhook subscription not found")
+
+// deliveryStatuses maps stored delivery states to their protobuf representation.
+var deliveryStatuses = map[string]pb.WebhookDeliveryStatus{
+	models.WebhookDeliveryPending:   pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING,
+	models.WebhookDeliveryDelivered: pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED,
+	models.WebhookDeliveryDead:      pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD,
+}
+
+// WebhookService manages partner webhook subscriptions and delivers user lifecycle events to them.
+// It implements events.EventPublisher: the outbox relay hands it every event, which it queues for
+// each interested subscription; the dispatcher then sends them (see webhook_dispatcher.go).
+type WebhookService struct {
+	Repo          *repositories.WebhookRepository
+	Audit         *AuditService
+	AllowInsecure bool // Accept http:// endpoints (local development only)
+	Dispatch      WebhookDispatchPolicy
+}
+
+// NewWebhookService creates a new WebhookService instance.
+func NewWebhookService(repo *repositories.WebhookRepository, audit *AuditService, cfg *config.Config) *WebhookService {
+	return &WebhookService{
+		Repo:          repo,
+		Audit:         audit,
+		AllowInsecure: cfg.WebhookAllowInsecureURLs,
+		Dispatch: WebhookDispatchPolicy{
+			MaxAttempts: max(cfg.WebhookMaxAttempts, 1),
+			BackoffBase: cfg.WebhookBackoffBase,
+			BackoffMax:  cfg.WebhookBackoffMax,
+			Timeout:     cfg.WebhookTimeout,
+			BatchSize:   max(cfg.WebhookBatchSize, 1),
+		},
+	}
+}
+
+// ✅ CreateWebhookSubscription
+func (s *WebhookService) CreateWebhookSubscription(ctx context.Context, req *pb.CreateWebhookSubscriptionRequest) (*pb.WebhookSubscription, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.validateURL(req.Url)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := eventTypeNames(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = generateWebhookSecret()
+	} else if len(secret) < minWebhookSecretLength || len(secret) > 255 {
+		return nil, status.Errorf(codes.InvalidArgument, "secret must be between %d and 255 characters", minWebhookSecretLength)
+	}
+
+	log.Printf("🔹 Creating webhook subscription | URL: %s | Events: %v", endpoint, eventTypes)
+
+	sub := &models.WebhookSubscription{
+		ID:         uuid.NewString(),
+		URL:        endpoint,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+		CreatedBy:  stringPtr(callerSubject(ctx)),
+	}
+	if err := s.Repo.CreateSubscription(sub); err != nil {
+		log.Printf("❌ Failed to create webhook subscription: %v", err)
+		return nil, err
+	}
+
+	log.Printf("✅ Webhook subscription created: %s", sub.ID)
+	if err := s.Audit.Record(ctx, models.AuditWebhookCreated, "", webhookAuditDetails(sub)); err != nil {
+		return nil, err
+	}
+	resp := toWebhookSubscriptionResponse(sub)
+	resp.Secret = sub.Secret
+	return resp, nil
+}
+
+// ✅ ListWebhookSubscriptions
+func (s *WebhookService) ListWebhookSubscriptions(ctx context.Context, req *pb.ListWebhookSubscriptionsRequest) (*pb.ListWebhookSubscriptionsResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	subs, err := s.Repo.ListSubscriptions()
+	if err != nil {
+		log.Printf("❌ Failed to list webhook subscriptions: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListWebhookSubscriptionsResponse{}
+	for _, sub := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, toWebhookSubscriptionResponse(sub))
+	}
+	return resp, nil
+}
+
+// ✅ UpdateWebhookSubscription
+func (s *WebhookService) UpdateWebhookSubscription(ctx context.Context, req *pb.UpdateWebhookSubscriptionRequest) (*pb.WebhookSubscription, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if uuid.Validate(req.Id) != nil {
+		return nil, status.Error(codes.InvalidArgument, "id must be a valid UUID")
+	}
+
+	var update models.WebhookSubscriptionUpdate
+	if req.Url != nil {
+		endpoint, err := s.validateURL(*req.Url)
+		if err != nil {
+			return nil, err
+		}
+		update.URL = &endpoint
+	}
+	if req.UpdateEventTypes {
+		eventTypes, err := eventTypeNames(req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		update.EventTypes = eventTypes
+	}
+	update.Active = req.Active
+
+	log.Printf("🔹 Updating webhook subscription: %s", req.Id)
+
+	sub, err := s.Repo.UpdateSubscription(req.Id, update)
+	if err != nil {
+		log.Printf("❌ Failed to update webhook subscription: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("✅ Webhook subscription updated: %s", sub.ID)
+	if err := s.Audit.Record(ctx, models.AuditWebhookUpdated, "", webhookAuditDetails(sub)); err != nil {
+		return nil, err
+	}
+	return toWebhookSubscriptionResponse(sub), nil
+}
+
+// ✅ DeleteWebhookSubscription
+func (s *WebhookService) DeleteWebhookSubscription(ctx context.Context, req *pb.DeleteWebhookSubscriptionRequest) (*pb.DeleteWebhookSubscriptionResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if uuid.Validate(req.Id) != nil {
+		return nil, status.Error(codes.InvalidArgument, "id must be a valid UUID")
+	}
+
+	log.Printf("🔹 Deleting webhook subscription: %s", req.Id)
+
+	if err := s.Repo.DeleteSubscription(req.Id); err != nil {
+		log.Printf("❌ Failed to delete webhook subscription: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	log.Printf("✅ Webhook subscription deleted: %s", req.Id)
+	if err := s.Audit.Record(ctx, models.AuditWebhookDeleted, "", map[string]string{"subscription_id": req.Id}); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteWebhookSubscriptionResponse{Message: "Webhook subscription deleted successfully"}, nil
+}
+
+// ✅ ListWebhookDeliveries
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if req.SubscriptionId != "" && uuid.Validate(req.SubscriptionId) != nil {
+		return nil, status.Error(codes.InvalidArgument, "subscription_id must be a valid UUID")
+	}
+
+	filter := repositories.WebhookDeliveryFilter{
+		SubscriptionID: req.SubscriptionId,
+		Limit:          pageSize(req.PageSize) + 1,
+	}
+	for name, value := range deliveryStatuses {
+		if value == req.Status {
+			filter.Status = name
+		}
+	}
+
+	token, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		if filter.BeforeID, err = strconv.ParseInt(token.ID, 10, 64); err != nil {
+			return nil, errInvalidPageToken
+		}
+	}
+
+	deliveries, err := s.Repo.ListDeliveries(filter)
+	if err != nil {
+		log.Printf("❌ Failed to list webhook deliveries: %v", err)
+		return nil, err
+	}
+
+	resp := &pb.ListWebhookDeliveriesResponse{}
+	if len(deliveries) == filter.Limit {
+		deliveries = deliveries[:len(deliveries)-1]
+		last := deliveries[len(deliveries)-1]
+		resp.NextPageToken = encodePageToken(pageToken{ID: strconv.FormatInt(last.ID, 10)})
+	}
+	for _, delivery := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, toWebhookDeliveryResponse(delivery))
+	}
+	return resp, nil
+}
+
+// ✅ RedeliverWebhook
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.WebhookDelivery, error) {
+	if err := requirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🔹 Redelivering webhook delivery: %d", req.DeliveryId)
+
+	delivery, err := s.Repo.Redeliver(req.DeliveryId)
+	if err != nil {
+		log.Printf("❌ Failed to requeue webhook delivery: %v", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "webhook delivery not found")
+		}
+		return nil, err
+	}
+
+	log.Printf("✅ Webhook delivery requeued: %d", delivery.ID)
+	if err := s.Audit.Record(ctx, models.AuditWebhookRedelivered, "", map[string]string{"delivery_id": strconv.FormatInt(delivery.ID, 10)}); err != nil {
+		return nil, err
+	}
+	return toWebhookDeliveryResponse(delivery), nil
+}
+
+// Publish implements events.EventPublisher by queuing the event for every interested subscription.
+func (s *WebhookService) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.Repo.EnqueueDeliveries(event.ID, event.EventType, body)
+	return err
+}
+
+// validateURL checks that a webhook endpoint is an absolute https URL (or http when allowed).
+func (s *WebhookService) validateURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" || len(raw) > 2048 {
+		return "", status.Error(codes.InvalidArgument, "url must be an absolute URL")
+	}
+	if parsed.Scheme != "https" && !(s.AllowInsecure && parsed.Scheme == "http") {
+		return "", status.Error(codes.InvalidArgument, "url must use https")
+	}
+	return parsed.String(), nil
+}
+
+// eventTypeNames converts requested event types to their stored names.
+func eventTypeNames(types []pb.UserEventType) ([]string, error) {
+	names := []string{}
+	for _, eventType := range types {
+		name := ""
+		for stored, value := range eventTypes {
+			if value == eventType {
+				name = stored
+			}
+		}
+		if name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported event type %s", eventType)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// generateWebhookSecret returns a random 256-bit secret.
+func generateWebhookSecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return "whsec_" + hex.EncodeToString(secret)
+}
+
+// webhookAuditDetails describes a subscription's current settings for the audit log. The secret is never included.
+func webhookAuditDetails(sub *models.WebhookSubscription) map[string]string {
+	return map[string]string{
+		"subscription_id": sub.ID,
+		"url":             sub.URL,
+		"event_types":     strings.Join(sub.EventTypes, ","),
+		"active":          strconv.FormatBool(sub.Active),
+	}
+}
+
+// toWebhookSubscriptionResponse converts a subscription into its protobuf representation, without the secret.
+func toWebhookSubscriptionResponse(sub *models.WebhookSubscription) *pb.WebhookSubscription {
+	resp := &pb.WebhookSubscription{
+		Id:        sub.ID,
+		Url:       sub.URL,
+		Active:    sub.Active,
+		CreatedAt: utils.ToProtoTimestamp(sub.CreatedAt),
+		UpdatedAt: utils.ToProtoTimestamp(sub.UpdatedAt),
+	}
+	for _, name := range sub.EventTypes {
+		resp.EventTypes = append(resp.EventTypes, eventTypes[name])
+	}
+	return resp
+}
+
+// toWebhookDeliveryResponse converts a delivery into its protobuf representation.
+func toWebhookDeliveryResponse(delivery *models.WebhookDelivery) *pb.WebhookDelivery {
+	resp := &pb.WebhookDelivery{
+		Id:             delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		EventId:        delivery.EventID,
+		EventType:      eventTypes[delivery.EventType],
+		Status:         deliveryStatuses[delivery.Status],
+		Attempts:       int32(delivery.Attempts),
+		LastError:      derefString(delivery.LastError),
+		CreatedAt:      utils.ToProtoTimestamp(delivery.CreatedAt),
+		LastAttemptAt:  utils.ToOptionalProtoTimestamp(delivery.LastAttemptAt),
+		DeliveredAt:    utils.ToOptionalProtoTimestamp(delivery.DeliveredAt),
+	}
+	if delivery.LastStatusCode != nil {
+		resp.LastStatusCode = int32(*delivery.LastStatusCode)
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		resp.NextAttemptAt = utils.ToProtoTimestamp(delivery.NextAttemptAt)
+	}
+	return resp
+}
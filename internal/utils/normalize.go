@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeEmail trims surrounding whitespace and lowercases the email.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizeUsername trims surrounding whitespace and applies Unicode NFKC
+// normalization so visually identical usernames compare equal. Case is kept
+// for display; uniqueness is enforced case-insensitively by the database.
+func NormalizeUsername(username string) string {
+	return norm.NFKC.String(strings.TrimSpace(username))
+}
+
+// UsernameKey returns the case-folded form of a username used for uniqueness checks.
+func UsernameKey(username string) string {
+	return strings.ToLower(NormalizeUsername(username))
+}
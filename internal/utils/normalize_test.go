@@ -0,0 +1,56 @@
+package utils
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"jane@example.com", "jane@example.com"},
+		{"  Jane.Doe@Example.COM \n", "jane.doe@example.com"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeEmail(tt.email); got != tt.want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+	}{
+		{"unchanged", "jane_doe", "jane_doe"},
+		{"case kept for display", " JaneDoe ", "JaneDoe"},
+		{"fullwidth folded", "ｊａｎｅ", "jane"},
+		{"ligature folded", "ﬁddler", "fiddler"},
+		{"combining accent composed", "Rene\u0301", "Ren\u00e9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeUsername(tt.username); got != tt.want {
+				t.Errorf("NormalizeUsername(%q) = %q, want %q", tt.username, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsernameKey(t *testing.T) {
+	tests := []struct {
+		username string
+		want     string
+	}{
+		{"jane_doe", "jane_doe"},
+		{" JaneDoe ", "janedoe"},
+		{"ＪＡＮＥ", "jane"},
+	}
+	for _, tt := range tests {
+		if got := UsernameKey(tt.username); got != tt.want {
+			t.Errorf("UsernameKey(%q) = %q, want %q", tt.username, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// trustedProxies are the networks whose "x-forwarded-for" headers are believed; set once at startup.
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies configures the load balancers allowed to report the client address, as IPs or CIDRs.
+func SetTrustedProxies(proxies []string) error {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		nets = append(nets, network)
+	}
+	trustedProxies = nets
+	return nil
+}
+
+// ClientIP returns the caller's IP address: the connection's peer address, or, when the peer is a
+// trusted proxy, the last "x-forwarded-for" entry not added by a trusted proxy. Headers from any
+// other peer are ignored, since clients can set them to anything.
+func ClientIP(ctx context.Context) string {
+	ip := peerIP(ctx)
+	if ip == nil {
+		return ""
+	}
+	if !isTrustedProxy(ip) {
+		return ip.String()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var hops []string
+	for _, header := range md.Get("x-forwarded-for") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !isTrustedProxy(hop) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+// peerIP returns the IP address of the connection's remote end.
+func peerIP(ctx context.Context) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	return net.ParseIP(host)
+}
+
+// isTrustedProxy reports whether ip belongs to a configured trusted proxy.
+func isTrustedProxy(ip net.IP) bool {
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserAgent returns the caller's "user-agent" metadata value.
+func UserAgent(ctx context.Context) string {
+	return firstMetadataValue(ctx, "user-agent")
+}
+
+// RequestID returns the caller's "x-request-id" metadata value, used to correlate logs and audit entries.
+func RequestID(ctx context.Context) string {
+	return firstMetadataValue(ctx, "x-request-id")
+}
+
+// firstMetadataValue returns the first incoming metadata value for key, or "".
+func firstMetadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestSetTrustedProxies(t *testing.T) {
+	defer func() { trustedProxies = nil }()
+
+	tests := []struct {
+		name    string
+		proxies []string
+		wantErr bool
+	}{
+		{name: "none", proxies: nil},
+		{name: "ipv4 and cidr", proxies: []string{"10.0.0.1", "192.168.0.0/16"}},
+		{name: "ipv6", proxies: []string{"fd00::1", "fd00::/8"}},
+		{name: "hostname", proxies: []string{"lb.internal"}, wantErr: true},
+		{name: "bad cidr", proxies: []string{"10.0.0.0/33"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetTrustedProxies(tt.proxies); (err != nil) != tt.wantErr {
+				t.Errorf("SetTrustedProxies(%v) = %v, want error: %v", tt.proxies, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	if err := SetTrustedProxies([]string{"10.0.0.0/8", "fd00::1"}); err != nil {
+		t.Fatalf("SetTrustedProxies() = %v", err)
+	}
+	defer func() { trustedProxies = nil }()
+
+	tests := []struct {
+		name         string
+		peer         string
+		forwardedFor []string
+		want         string
+	}{
+		{name: "no peer", want: ""},
+		{name: "direct client", peer: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "direct client spoofing the header", peer: "203.0.113.7:5000", forwardedFor: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "trusted proxy", peer: "10.0.0.5:443", forwardedFor: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "trusted ipv6 proxy", peer: "[fd00::1]:443", forwardedFor: []string{"2001:db8::5"}, want: "2001:db8::5"},
+		{name: "spoofed entry before the client", peer: "10.0.0.5:443", forwardedFor: []string{"1.2.3.4, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "chained trusted proxies", peer: "10.0.0.5:443", forwardedFor: []string{"198.51.100.1, 10.1.1.1", "10.2.2.2"}, want: "198.51.100.1"},
+		{name: "garbage entry", peer: "10.0.0.5:443", forwardedFor: []string{"198.51.100.1, unknown"}, want: "10.0.0.5"},
+		{name: "trusted proxy without header", peer: "10.0.0.5:443", want: "10.0.0.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.peer != "" {
+				addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+				if err != nil {
+					t.Fatalf("ResolveTCPAddr(%q) = %v", tt.peer, err)
+				}
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+			}
+			if len(tt.forwardedFor) > 0 {
+				md := metadata.MD{"x-forwarded-for": tt.forwardedFor}
+				ctx = metadata.NewIncomingContext(ctx, md)
+			}
+			if got := ClientIP(ctx); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
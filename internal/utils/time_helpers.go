@@ -2,13 +2,53 @@ package utils
 
 import (
 	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Timestamps leave the service either as google.protobuf.Timestamp (ToProtoTimestamp) or,
+// for deprecated string fields and user-facing messages, as RFC3339 (FormatTimestamp).
+// Convert through these helpers rather than formatting times inline.
+
 // FormatTimestamp formats a time.Time object into RFC3339 format.
 func FormatTimestamp(t time.Time) string {
 	return t.Format(time.RFC3339)
 }
 
+// FormatOptionalTimestamp formats a nullable time into RFC3339, returning "" when unset.
+func FormatOptionalTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return FormatTimestamp(*t)
+}
+
+// ToProtoTimestamp converts a time.Time into a protobuf Timestamp, keeping sub-second precision.
+func ToProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	return timestamppb.New(t)
+}
+
+// ToOptionalProtoTimestamp converts a nullable time into a protobuf Timestamp, returning nil when unset.
+func ToOptionalProtoTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// FromOptionalProtoTimestamp converts an optional protobuf Timestamp from a request into a UTC
+// time.Time, returning nil when unset and an error when it is out of range.
+func FromOptionalProtoTimestamp(ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, err
+	}
+	t := ts.AsTime().UTC()
+	return &t, nil
+}
+
 // ParseTimestamp parses an RFC3339 timestamp into a time.Time object.
 func ParseTimestamp(timestamp string) (time.Time, error) {
 	return time.Parse(time.RFC3339, timestamp)
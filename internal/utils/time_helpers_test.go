@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFromOptionalProtoTimestamp(t *testing.T) {
+	instant := time.Date(2026, 5, 4, 10, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name    string
+		ts      *timestamppb.Timestamp
+		want    *time.Time
+		wantErr bool
+	}{
+		{name: "unset", ts: nil, want: nil},
+		{name: "set", ts: timestamppb.New(instant), want: &instant},
+		{name: "from another zone", ts: timestamppb.New(instant.In(time.FixedZone("CEST", 2*60*60))), want: &instant},
+		{name: "nanos out of range", ts: &timestamppb.Timestamp{Seconds: 0, Nanos: 1e9}, wantErr: true},
+		{name: "after year 9999", ts: &timestamppb.Timestamp{Seconds: 253402300800}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromOptionalProtoTimestamp(tt.ts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromOptionalProtoTimestamp() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("FromOptionalProtoTimestamp() = %v, want %v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if !got.Equal(*tt.want) || got.Location() != time.UTC {
+				t.Errorf("FromOptionalProtoTimestamp() = %v, want %v in UTC", *got, *tt.want)
+			}
+		})
+	}
+}
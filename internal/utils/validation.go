@@ -2,7 +2,14 @@ package utils
 
 import (
 	"errors"
+	"net/url"
 	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
 )
 
 // ValidateEmail ensures the email follows a valid format.
@@ -33,3 +40,68 @@ func ValidateAuth0ID(auth0ID string) error {
 	}
 	return nil
 }
+
+// ValidateDisplayName ensures the display name is at most 50 characters without control characters.
+func ValidateDisplayName(displayName string) error {
+	if utf8.RuneCountInString(displayName) > 50 {
+		return errors.New("display name must be at most 50 characters")
+	}
+	if strings.IndexFunc(displayName, unicode.IsControl) >= 0 {
+		return errors.New("display name cannot contain control characters")
+	}
+	return nil
+}
+
+// ValidateAvatarURL ensures the avatar URL is an absolute https URL of reasonable length.
+func ValidateAvatarURL(avatarURL string) error {
+	if len(avatarURL) > 2048 {
+		return errors.New("avatar URL must be at most 2048 characters")
+	}
+	parsed, err := url.Parse(avatarURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.New("avatar URL must be an absolute https URL")
+	}
+	return nil
+}
+
+// ValidateBio ensures the bio is at most 500 characters.
+func ValidateBio(bio string) error {
+	if utf8.RuneCountInString(bio) > 500 {
+		return errors.New("bio must be at most 500 characters")
+	}
+	return nil
+}
+
+// ValidateLocale ensures the locale is a well-formed BCP 47 language tag (e.g. "en-US").
+func ValidateLocale(locale string) error {
+	if len(locale) > 35 {
+		return errors.New("locale must be at most 35 characters")
+	}
+	if _, err := language.Parse(locale); err != nil {
+		return errors.New("locale must be a BCP 47 language tag")
+	}
+	return nil
+}
+
+// ValidateTimezone ensures the timezone is an IANA time zone name (e.g. "Europe/Berlin").
+func ValidateTimezone(timezone string) error {
+	if timezone == "Local" {
+		return errors.New("timezone must be an IANA time zone name")
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return errors.New("timezone must be an IANA time zone name")
+	}
+	return nil
+}
+
+// ValidateDateOfBirth ensures the date is a YYYY-MM-DD calendar date in the past, after 1900.
+func ValidateDateOfBirth(dateOfBirth string) error {
+	date, err := time.Parse(time.DateOnly, dateOfBirth)
+	if err != nil {
+		return errors.New("date of birth must be formatted as YYYY-MM-DD")
+	}
+	if date.Year() < 1900 || !date.Before(time.Now()) {
+		return errors.New("date of birth must be between 1900 and today")
+	}
+	return nil
+}
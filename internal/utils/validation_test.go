@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidators(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+
+	tests := []struct {
+		name     string
+		validate func(string) error
+		value    string
+		wantErr  bool
+	}{
+		{"email", ValidateEmail, "jane.doe+band@example.com", false},
+		{"email without domain", ValidateEmail, "jane@", true},
+		{"email without tld", ValidateEmail, "jane@example", true},
+
+		{"username", ValidateUsername, "jane_doe42", false},
+		{"username too short", ValidateUsername, "jd", true},
+		{"username too long", ValidateUsername, strings.Repeat("a", 51), true},
+		{"username with dash", ValidateUsername, "jane-doe", true},
+
+		{"auth0 id", ValidateAuth0ID, "auth0|123", false},
+		{"auth0 id empty", ValidateAuth0ID, "", true},
+
+		{"display name", ValidateDisplayName, "Jane Doe 🎸", false},
+		{"display name of 50 runes", ValidateDisplayName, strings.Repeat("é", 50), false},
+		{"display name too long", ValidateDisplayName, strings.Repeat("é", 51), true},
+		{"display name with newline", ValidateDisplayName, "Jane\nDoe", true},
+
+		{"avatar url", ValidateAvatarURL, "https://cdn.example.com/a.png", false},
+		{"avatar url over http", ValidateAvatarURL, "http://cdn.example.com/a.png", true},
+		{"avatar url without host", ValidateAvatarURL, "https:///a.png", true},
+		{"avatar url relative", ValidateAvatarURL, "/a.png", true},
+		{"avatar url too long", ValidateAvatarURL, "https://cdn.example.com/" + strings.Repeat("a", 2048), true},
+
+		{"bio", ValidateBio, strings.Repeat("é", 500), false},
+		{"bio too long", ValidateBio, strings.Repeat("é", 501), true},
+
+		{"locale", ValidateLocale, "en-US", false},
+		{"locale language only", ValidateLocale, "de", false},
+		{"locale malformed", ValidateLocale, "en_US!", true},
+		{"locale too long", ValidateLocale, "en-" + strings.Repeat("a", 33), true},
+
+		{"timezone", ValidateTimezone, "UTC", false},
+		{"timezone local", ValidateTimezone, "Local", true},
+		{"timezone unknown", ValidateTimezone, "Mars/Olympus_Mons", true},
+		{"timezone path", ValidateTimezone, "../etc/passwd", true},
+
+		{"date of birth", ValidateDateOfBirth, "1990-04-12", false},
+		{"date of birth not a date", ValidateDateOfBirth, "12/04/1990", true},
+		{"date of birth invalid day", ValidateDateOfBirth, "1990-02-30", true},
+		{"date of birth before 1900", ValidateDateOfBirth, "1899-12-31", true},
+		{"date of birth in the future", ValidateDateOfBirth, tomorrow, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.validate(tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("validate(%q) = %v, want error: %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}